@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/fsutil"
+)
+
+// ErrNotCached is returned when no cached copy of a dataset exists
+var ErrNotCached = errors.New("dataset not cached")
+
+// Cache persists raw API responses on disk so the TUI can start offline
+type Cache struct {
+	dir string
+}
+
+// CacheEntry is a single cached API response
+type CacheEntry struct {
+	URL          string          `json:"url"`
+	GeneratedAt  string          `json:"generated_at"`
+	FetchedAt    time.Time       `json:"fetched_at"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	Body         json.RawMessage `json:"body"`
+}
+
+// NewCache creates a cache rooted at dir
+func NewCache(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// DefaultCacheDir returns the cmvp directory under the user's cache dir
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cmvp"), nil
+}
+
+// DefaultCache returns a cache in DefaultCacheDir, or nil if there is no
+// usable cache directory on this system
+func DefaultCache() *Cache {
+	dir, err := DefaultCacheDir()
+	if err != nil {
+		return nil
+	}
+	return NewCache(dir)
+}
+
+// Dir returns the directory the cache writes to
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Load returns the cached response for endpoint if it was fetched from url
+func (c *Cache) Load(endpoint, url string) (*CacheEntry, error) {
+	data, err := os.ReadFile(c.path(endpoint))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotCached
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	// Entries from a different upstream are as good as missing
+	if entry.URL != url {
+		return nil, ErrNotCached
+	}
+	return &entry, nil
+}
+
+// Store writes the response body for endpoint, tagged with its generated_at
+func (c *Cache) Store(endpoint string, entry CacheEntry) error {
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(c.path(endpoint), data, 0o600)
+}
+
+// path maps an endpoint such as "/modules.json" to its cache file
+func (c *Cache) path(endpoint string) string {
+	return filepath.Join(c.dir, strings.TrimPrefix(endpoint, "/"))
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCache_StoreLoad(t *testing.T) {
+	cache := NewCache(t.TempDir())
+
+	entry := CacheEntry{
+		URL:         "https://example.com/api/modules.json",
+		GeneratedAt: "2024-01-15T10:00:00Z",
+		FetchedAt:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+		Body:        json.RawMessage(`{"modules":[]}`),
+	}
+	if err := cache.Store(ModulesEndpoint, entry); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	got, err := cache.Load(ModulesEndpoint, entry.URL)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got.GeneratedAt != entry.GeneratedAt {
+		t.Errorf("GeneratedAt = %q, want %q", got.GeneratedAt, entry.GeneratedAt)
+	}
+	if !got.FetchedAt.Equal(entry.FetchedAt) {
+		t.Errorf("FetchedAt = %v, want %v", got.FetchedAt, entry.FetchedAt)
+	}
+	if string(got.Body) != string(entry.Body) {
+		t.Errorf("Body = %s, want %s", got.Body, entry.Body)
+	}
+}
+
+func TestCache_Load_Missing(t *testing.T) {
+	cache := NewCache(t.TempDir())
+
+	_, err := cache.Load(ModulesEndpoint, "https://example.com/api/modules.json")
+	if !errors.Is(err, ErrNotCached) {
+		t.Errorf("Load() error = %v, want ErrNotCached", err)
+	}
+}
+
+func TestCache_Load_DifferentURL(t *testing.T) {
+	cache := NewCache(t.TempDir())
+
+	if err := cache.Store(ModulesEndpoint, CacheEntry{
+		URL:  "https://mirror-a.example.com/modules.json",
+		Body: json.RawMessage(`{}`),
+	}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	_, err := cache.Load(ModulesEndpoint, "https://mirror-b.example.com/modules.json")
+	if !errors.Is(err, ErrNotCached) {
+		t.Errorf("Load() error = %v, want ErrNotCached", err)
+	}
+}
+
+func TestCache_Store_NoTempFilesLeft(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCache(dir)
+
+	if err := cache.Store(InProcessEndpoint, CacheEntry{Body: json.RawMessage(`{}`)}); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Name() != filepath.Base(InProcessEndpoint) {
+		t.Errorf("cache dir contains %v, want only %s", entries, filepath.Base(InProcessEndpoint))
+	}
+}
@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/ethanolivertroy/cmvp-tui/internal/model"
@@ -24,81 +28,424 @@ const (
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
+	cache      *Cache
+	retry      RetryPolicy
+	dir        string // Local directory the datasets are read from, if any
+
+	mu          sync.Mutex
+	generatedAt string
+	warnings    map[Dataset][]SchemaWarning         // Schema drift found in the last decode of each dataset
+	byCert      map[Dataset]map[string]model.Module // Last fetch of each dataset by certificate number
 }
 
-// NewClient creates a new API client
-func NewClient() *Client {
-	return &Client{
+// NewClient creates a new API client backed by the default on-disk cache
+func NewClient(opts ...Option) *Client {
+	c := &Client{
 		httpClient: &http.Client{Timeout: 30 * time.Second},
 		baseURL:    BaseURL,
+		cache:      DefaultCache(),
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	if c.dir != "" {
+		// Copy so a client passed to WithHTTPClient is not modified
+		hc := *c.httpClient
+		hc.Transport = dirTransport(c.dir)
+		c.httpClient = &hc
+	}
+	return c
+}
+
+// Source describes where the client reads its data from
+func (c *Client) Source() string {
+	if c.dir != "" {
+		return c.dir
+	}
+	return c.baseURL
 }
 
 // FetchAllModules fetches all three datasets and combines them
 func (c *Client) FetchAllModules() ([]model.Module, error) {
+	return c.FetchAllModulesContext(context.Background(), nil)
+}
+
+// FetchAllModulesContext fetches all three datasets in parallel and combines
+// them in Datasets order. If progress is non-nil it is called as each dataset
+// finishes.
+//
+// A failed dataset does not discard the others: the modules that did load are
+// returned together with a *FetchError naming each dataset that failed.
+//
+// If the metadata endpoint reports the same generated_at as the cached
+// datasets, they are served from the cache without downloading them again.
+func (c *Client) FetchAllModulesContext(ctx context.Context, progress ProgressFunc) ([]model.Module, error) {
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+
+	if results, ok := c.cachedIfCurrent(ctx); ok {
+		var allModules []model.Module
+		for i, ds := range Datasets {
+			progress(Progress{Dataset: ds, Count: len(results[i])})
+			allModules = append(allModules, results[i]...)
+		}
+		return allModules, nil
+	}
+
+	return fetchAll(ctx, progress, c.FetchDatasetContext)
+}
+
+// fetchAll runs fetch for every dataset in parallel and combines the results
+// in Datasets order, collecting failures into a *FetchError
+func fetchAll(ctx context.Context, progress ProgressFunc, fetch func(context.Context, Dataset) ([]model.Module, error)) ([]model.Module, error) {
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+
+	results := make([][]model.Module, len(Datasets))
+	errs := make([]error, len(Datasets))
+	var wg sync.WaitGroup
+	for i, ds := range Datasets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fetch(ctx, ds)
+			progress(Progress{Dataset: ds, Count: len(results[i]), Err: errs[i]})
+		}()
+	}
+	wg.Wait()
+
 	var allModules []model.Module
+	var fetchErr FetchError
+	for i, ds := range Datasets {
+		if errs[i] != nil {
+			fetchErr.Errors = append(fetchErr.Errors, &DatasetError{Dataset: ds, Err: errs[i]})
+			continue
+		}
+		allModules = append(allModules, results[i]...)
+	}
 
-	// Fetch active modules
-	active, err := c.fetchModules(ModulesEndpoint, model.StatusActive)
+	if len(fetchErr.Errors) > 0 {
+		return allModules, &fetchErr
+	}
+	return allModules, nil
+}
+
+// FetchDatasetContext fetches a single dataset
+func (c *Client) FetchDatasetContext(ctx context.Context, ds Dataset) ([]model.Module, error) {
+	if ds == DatasetInProcess {
+		return c.fetchInProcessModules(ctx)
+	}
+	return c.fetchModules(ctx, ds)
+}
+
+// CachedModules returns all modules from the on-disk cache without touching
+// the network, along with the generated_at tag of the cached data.
+// It returns ErrNotCached if nothing has been cached yet.
+func (c *Client) CachedModules() ([]model.Module, string, error) {
+	results, entries, err := c.loadCache()
 	if err != nil {
-		return nil, fmt.Errorf("fetching active modules: %w", err)
+		return nil, "", err
+	}
+
+	var allModules []model.Module
+	var generatedAt string
+	for i, entry := range entries {
+		if entry == nil {
+			continue
+		}
+		if generatedAt == "" {
+			generatedAt = entry.GeneratedAt
+		}
+		allModules = append(allModules, results[i]...)
+		c.setByCert(Datasets[i], results[i], false)
+	}
+	return allModules, generatedAt, nil
+}
+
+// cachedIfCurrent returns the cached datasets when every one of them carries
+// the generated_at currently reported by the metadata endpoint
+func (c *Client) cachedIfCurrent(ctx context.Context) ([][]model.Module, bool) {
+	if c.cache == nil {
+		return nil, false
 	}
-	allModules = append(allModules, active...)
 
-	// Fetch historical modules
-	historical, err := c.fetchModules(HistoricalEndpoint, model.StatusHistorical)
+	metadata, err := c.FetchMetadataContext(ctx)
+	if err != nil || metadata.GeneratedAt == "" {
+		return nil, false
+	}
+
+	results, entries, err := c.loadCache()
 	if err != nil {
-		return nil, fmt.Errorf("fetching historical modules: %w", err)
+		return nil, false
+	}
+	for _, entry := range entries {
+		if entry == nil || entry.GeneratedAt != metadata.GeneratedAt {
+			return nil, false
+		}
+	}
+
+	c.mu.Lock()
+	c.generatedAt = metadata.GeneratedAt
+	c.mu.Unlock()
+	for i, ds := range Datasets {
+		c.setByCert(ds, results[i], true)
+	}
+	return results, true
+}
+
+// loadCache decodes every cached dataset. results and entries are indexed
+// like Datasets; entries holds nil where a dataset is not cached.
+func (c *Client) loadCache() ([][]model.Module, []*CacheEntry, error) {
+	if c.cache == nil {
+		return nil, nil, ErrNotCached
 	}
-	allModules = append(allModules, historical...)
 
-	// Fetch in-process modules
-	inProcess, err := c.fetchInProcessModules()
+	results := make([][]model.Module, len(Datasets))
+	entries := make([]*CacheEntry, len(Datasets))
+	found := false
+
+	for i, ds := range Datasets {
+		entry, err := c.cache.Load(ds.Endpoint(), c.baseURL+ds.Endpoint())
+		if errors.Is(err, ErrNotCached) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		modules, _, err := decodeDataset(ds, entry.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("decoding cached %s: %w", ds, err)
+		}
+		c.validate(ds, entry.Body)
+
+		found = true
+		results[i] = modules
+		entries[i] = entry
+	}
+
+	if !found {
+		return nil, nil, ErrNotCached
+	}
+	return results, entries, nil
+}
+
+// GeneratedAt returns the generated_at timestamp of the most recently
+// fetched active modules dataset
+func (c *Client) GeneratedAt() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generatedAt
+}
+
+// SchemaWarnings returns the schema drift found in the most recently decoded
+// copy of each dataset, in Datasets order
+func (c *Client) SchemaWarnings() []SchemaWarning {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var warnings []SchemaWarning
+	for _, ds := range Datasets {
+		warnings = append(warnings, c.warnings[ds]...)
+	}
+	return warnings
+}
+
+// validate records and returns the schema drift in a dataset payload. It
+// runs before decoding, so drift that makes decoding fail is still reported.
+func (c *Client) validate(ds Dataset, body []byte) []SchemaWarning {
+	warnings, err := ValidateDataset(ds, body)
 	if err != nil {
-		return nil, fmt.Errorf("fetching in-process modules: %w", err)
+		return nil
 	}
-	allModules = append(allModules, inProcess...)
 
-	return allModules, nil
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.warnings == nil {
+		c.warnings = make(map[Dataset][]SchemaWarning)
+	}
+	c.warnings[ds] = warnings
+	return warnings
 }
 
 // FetchMetadata fetches the metadata from the API
 func (c *Client) FetchMetadata() (*MetadataJSON, error) {
-	resp, err := c.httpClient.Get(c.baseURL + MetadataEndpoint)
+	return c.FetchMetadataContext(context.Background())
+}
+
+// FetchMetadataContext fetches the metadata from the API
+func (c *Client) FetchMetadataContext(ctx context.Context) (*MetadataJSON, error) {
+	resp, err := c.get(ctx, MetadataEndpoint, "metadata")
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d for metadata", resp.StatusCode)
-	}
-
-	body := io.LimitReader(resp.Body, maxResponseSize)
 	var metadata MetadataJSON
-	if err := json.NewDecoder(body).Decode(&metadata); err != nil {
+	if err := json.Unmarshal(resp.body, &metadata); err != nil {
 		return nil, err
 	}
 
 	return &metadata, nil
 }
 
-func (c *Client) fetchModules(endpoint string, status model.ModuleStatus) ([]model.Module, error) {
-	resp, err := c.httpClient.Get(c.baseURL + endpoint)
+func (c *Client) fetchModules(ctx context.Context, ds Dataset) ([]model.Module, error) {
+	endpoint := ds.Endpoint()
+	resp, err := c.get(ctx, endpoint, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := c.validate(ds, resp.body)
+	modules, metadata, err := decodeModules(resp.body, ds.Status())
+	if err != nil {
+		return nil, schemaError(ds, warnings, err)
+	}
+
+	c.mu.Lock()
+	if ds == DatasetActive {
+		c.generatedAt = metadata.GeneratedAt
+	}
+	c.mu.Unlock()
+	c.setByCert(ds, modules, true)
+
+	c.store(endpoint, metadata.GeneratedAt, resp)
+	return modules, nil
+}
+
+func (c *Client) fetchInProcessModules(ctx context.Context) ([]model.Module, error) {
+	resp, err := c.get(ctx, InProcessEndpoint, "in-process modules")
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := c.validate(DatasetInProcess, resp.body)
+	modules, metadata, err := decodeInProcessModules(resp.body)
+	if err != nil {
+		return nil, schemaError(DatasetInProcess, warnings, err)
+	}
+
+	c.store(InProcessEndpoint, metadata.GeneratedAt, resp)
+	return modules, nil
+}
+
+// response is a response body along with its cache validators
+type response struct {
+	body         []byte
+	etag         string
+	lastModified string
+	notModified  bool // true when the server answered 304 and body came from the cache
+}
+
+// get fetches endpoint and returns the raw response body.
+// If the endpoint is cached, the request is made conditional on the cached
+// ETag and Last-Modified validators and a 304 is answered from the cache.
+// name identifies the endpoint in error messages.
+func (c *Client) get(ctx context.Context, endpoint, name string) (*response, error) {
+	url := c.baseURL + endpoint
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached *CacheEntry
+	if c.cache != nil {
+		if entry, err := c.cache.Load(endpoint, url); err == nil {
+			cached = entry
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	resp, err := c.do(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return &response{
+			body:         cached.Body,
+			etag:         cached.ETag,
+			lastModified: cached.LastModified,
+			notModified:  true,
+		}, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d for %s", resp.StatusCode, endpoint)
+		return nil, &HTTPStatusError{Code: resp.StatusCode, Name: name}
 	}
 
-	body := io.LimitReader(resp.Body, maxResponseSize)
-	var response ModulesResponse
-	if err := json.NewDecoder(body).Decode(&response); err != nil {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
 		return nil, err
 	}
+	return &response{
+		body:         body,
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// do sends req, retrying transport errors, 429 and 5xx responses according
+// to the client's retry policy. The last response or error is returned.
+func (c *Client) do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.httpClient.Do(req)
+		if attempt >= c.retry.attempts() || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		d := c.retry.delay(attempt, resp)
+		if resp != nil {
+			// Drain so the connection can be reused for the next attempt
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+			resp.Body.Close()
+		}
+		if err := sleep(ctx, d); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// store saves a successfully decoded response body to the cache.
+// Cache failures are not fatal: the fresh data is still returned to the caller.
+func (c *Client) store(endpoint, generatedAt string, resp *response) {
+	// A 304 means the cached copy is already current
+	if c.cache == nil || resp.notModified {
+		return
+	}
+	_ = c.cache.Store(endpoint, CacheEntry{
+		URL:          c.baseURL + endpoint,
+		GeneratedAt:  generatedAt,
+		FetchedAt:    time.Now().UTC(),
+		ETag:         resp.etag,
+		LastModified: resp.lastModified,
+		Body:         resp.body,
+	})
+}
+
+// decodeDataset converts the payload of any dataset
+func decodeDataset(ds Dataset, body []byte) ([]model.Module, MetadataJSON, error) {
+	if ds == DatasetInProcess {
+		return decodeInProcessModules(body)
+	}
+	return decodeModules(body, ds.Status())
+}
+
+// decodeModules converts an active or historical modules payload
+func decodeModules(body []byte, status model.ModuleStatus) ([]model.Module, MetadataJSON, error) {
+	var response ModulesResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, MetadataJSON{}, err
+	}
 
 	modules := make([]model.Module, len(response.Modules))
 	for i, jm := range response.Modules {
@@ -114,7 +461,7 @@ func (c *Client) fetchModules(endpoint string, status model.ModuleStatus) ([]mod
 			// Extended fields
 			Standard:           jm.Standard,
 			OverallLevel:       parseOverallLevel(jm.OverallLevel),
-			SunsetDate:         jm.SunsetDate,
+			SunsetDate:         parseDate(jm.SunsetDate),
 			Caveat:             jm.Caveat,
 			Embodiment:         jm.Embodiment,
 			Description:        jm.Description,
@@ -124,24 +471,14 @@ func (c *Client) fetchModules(endpoint string, status model.ModuleStatus) ([]mod
 			SecurityPolicyURL:  jm.SecurityPolicyURL,
 		}
 	}
-	return modules, nil
+	return modules, response.Metadata, nil
 }
 
-func (c *Client) fetchInProcessModules() ([]model.Module, error) {
-	resp, err := c.httpClient.Get(c.baseURL + InProcessEndpoint)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d for in-process modules", resp.StatusCode)
-	}
-
-	body := io.LimitReader(resp.Body, maxResponseSize)
+// decodeInProcessModules converts a modules-in-process payload
+func decodeInProcessModules(body []byte) ([]model.Module, MetadataJSON, error) {
 	var response InProcessModulesResponse
-	if err := json.NewDecoder(body).Decode(&response); err != nil {
-		return nil, err
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, MetadataJSON{}, err
 	}
 
 	modules := make([]model.Module, len(response.Modules))
@@ -151,34 +488,52 @@ func (c *Client) fetchInProcessModules() ([]model.Module, error) {
 			CertificateURL:    "",
 			VendorName:        jm.VendorName,
 			ModuleName:        jm.ModuleName,
-			ModuleType:        jm.Standard, // Use Standard field as module type
 			ValidationDate:    time.Time{}, // No validation date yet
 			Status:            model.StatusInProcess,
+			Stage:             model.ParseProcessStage(jm.Status),
+			Standard:          jm.Standard,
 		}
 	}
-	return modules, nil
+	return modules, response.Metadata, nil
 }
 
-// parseOverallLevel converts the API overall_level (can be int or string) to int
-func parseOverallLevel(v interface{}) int {
+// parseOverallLevel converts the API overall_level, which can be a number or
+// text such as "Tested Configuration(s)"
+func parseOverallLevel(v interface{}) model.SecurityLevel {
 	switch val := v.(type) {
 	case float64:
-		return int(val)
+		return model.NewSecurityLevel(int(val))
 	case int:
-		return val
+		return model.NewSecurityLevel(val)
+	case string:
+		return model.ParseSecurityLevel(val)
 	default:
-		return 0 // String values like "Tested Configuration(s)" become 0
+		return model.SecurityLevel{}
 	}
 }
 
-// parseDate parses a date string in MM/DD/YYYY format
+// dateLayouts are the date formats seen in upstream data, most common first
+var dateLayouts = []string{
+	"01/02/2006",
+	"1/2/2006",
+	"2006-01-02",
+	time.RFC3339,
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2006/01/02",
+}
+
+// parseDate parses a date in any of dateLayouts, such as MM/DD/YYYY, and
+// returns the zero time if none match
 func parseDate(dateStr string) time.Time {
+	dateStr = strings.TrimSpace(dateStr)
 	if dateStr == "" {
 		return time.Time{}
 	}
-	t, err := time.Parse("01/02/2006", dateStr)
-	if err != nil {
-		return time.Time{}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, dateStr); err == nil {
+			return t
+		}
 	}
-	return t
+	return time.Time{}
 }
@@ -1,9 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,6 +27,21 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_Options(t *testing.T) {
+	cache := NewCache(t.TempDir())
+	client := NewClient(WithCache(cache), WithRetryPolicy(NoRetry))
+
+	if client.cache != cache {
+		t.Error("WithCache() was not applied")
+	}
+	if client.retry != NoRetry {
+		t.Errorf("retry = %+v, want %+v", client.retry, NoRetry)
+	}
+	if NewClient().retry != DefaultRetryPolicy {
+		t.Error("expected NewClient() to use DefaultRetryPolicy")
+	}
+}
+
 func TestClient_FetchAllModules(t *testing.T) {
 	// Create mock responses
 	modulesResp := ModulesResponse{
@@ -52,6 +71,7 @@ func TestClient_FetchAllModules(t *testing.T) {
 				ModuleName: "In Process Module",
 				VendorName: "IP Vendor",
 				Standard:   "FIPS 140-3",
+				Status:     "In Review",
 			},
 		},
 	}
@@ -105,6 +125,300 @@ func TestClient_FetchAllModules(t *testing.T) {
 	if modules[2].Status != model.StatusInProcess {
 		t.Errorf("third module status = %v, want StatusInProcess", modules[2].Status)
 	}
+	if modules[2].Stage != model.StageInReview {
+		t.Errorf("third module stage = %q, want In Review", modules[2].Stage)
+	}
+	if modules[2].Standard != "FIPS 140-3" {
+		t.Errorf("third module standard = %q, want FIPS 140-3", modules[2].Standard)
+	}
+}
+
+func TestClient_CachedModules(t *testing.T) {
+	modulesResp := ModulesResponse{
+		Metadata: MetadataJSON{GeneratedAt: "2024-01-15T10:00:00Z"},
+		Modules: []ModuleJSON{
+			{CertificateNumber: "1234", ModuleName: "Cached Module"},
+		},
+	}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/modules-in-process.json" {
+			json.NewEncoder(w).Encode(InProcessModulesResponse{})
+			return
+		}
+		json.NewEncoder(w).Encode(modulesResp)
+	}))
+	defer server.Close()
+
+	cache := NewCache(t.TempDir())
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		cache:      cache,
+	}
+
+	if _, _, err := client.CachedModules(); err != ErrNotCached {
+		t.Fatalf("CachedModules() before fetch error = %v, want ErrNotCached", err)
+	}
+
+	if _, err := client.FetchAllModules(); err != nil {
+		t.Fatalf("FetchAllModules() error = %v", err)
+	}
+	if got := client.GeneratedAt(); got != "2024-01-15T10:00:00Z" {
+		t.Errorf("GeneratedAt() = %q, want 2024-01-15T10:00:00Z", got)
+	}
+
+	fetched := requests.Load()
+	modules, generatedAt, err := client.CachedModules()
+	if err != nil {
+		t.Fatalf("CachedModules() error = %v", err)
+	}
+	if got := requests.Load(); got != fetched {
+		t.Errorf("CachedModules() made %d network requests, want 0", got-fetched)
+	}
+	if generatedAt != "2024-01-15T10:00:00Z" {
+		t.Errorf("generatedAt = %q, want 2024-01-15T10:00:00Z", generatedAt)
+	}
+	// Active and historical both return the same single module
+	if len(modules) != 2 {
+		t.Fatalf("got %d cached modules, want 2", len(modules))
+	}
+	if modules[0].Status != model.StatusActive || modules[1].Status != model.StatusHistorical {
+		t.Errorf("cached statuses = %v, %v; want Active, Historical", modules[0].Status, modules[1].Status)
+	}
+}
+
+func TestClient_FetchAllModules_ConditionalRequests(t *testing.T) {
+	var conditional, full atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/metadata.json" {
+			http.NotFound(w, r)
+			return
+		}
+		etag := `"v1-` + r.URL.Path + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			conditional.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		full.Add(1)
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/modules-in-process.json" {
+			json.NewEncoder(w).Encode(InProcessModulesResponse{
+				Modules: []InProcessModuleJSON{{ModuleName: "IP Module"}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(ModulesResponse{
+			Modules: []ModuleJSON{{CertificateNumber: "1234"}},
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		cache:      NewCache(t.TempDir()),
+	}
+
+	if _, err := client.FetchAllModules(); err != nil {
+		t.Fatalf("first FetchAllModules() error = %v", err)
+	}
+	if full.Load() != 3 || conditional.Load() != 0 {
+		t.Fatalf("first fetch: full=%d conditional=%d, want 3 and 0", full.Load(), conditional.Load())
+	}
+
+	modules, err := client.FetchAllModules()
+	if err != nil {
+		t.Fatalf("second FetchAllModules() error = %v", err)
+	}
+	if full.Load() != 3 || conditional.Load() != 3 {
+		t.Errorf("second fetch: full=%d conditional=%d, want 3 and 3", full.Load(), conditional.Load())
+	}
+	if len(modules) != 3 {
+		t.Errorf("got %d modules from 304 responses, want 3", len(modules))
+	}
+}
+
+func TestClient_FetchAllModules_UnchangedMetadataSkipsDownloads(t *testing.T) {
+	const generatedAt = "2024-01-15T10:00:00Z"
+	var datasetRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		metadata := MetadataJSON{GeneratedAt: generatedAt}
+		switch r.URL.Path {
+		case "/api/metadata.json":
+			json.NewEncoder(w).Encode(metadata)
+		case "/api/modules-in-process.json":
+			datasetRequests.Add(1)
+			json.NewEncoder(w).Encode(InProcessModulesResponse{Metadata: metadata})
+		default:
+			datasetRequests.Add(1)
+			json.NewEncoder(w).Encode(ModulesResponse{
+				Metadata: metadata,
+				Modules:  []ModuleJSON{{CertificateNumber: "1234"}},
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		cache:      NewCache(t.TempDir()),
+	}
+
+	if _, err := client.FetchAllModules(); err != nil {
+		t.Fatalf("first FetchAllModules() error = %v", err)
+	}
+	if got := datasetRequests.Load(); got != 3 {
+		t.Fatalf("first fetch made %d dataset requests, want 3", got)
+	}
+
+	modules, err := client.FetchAllModules()
+	if err != nil {
+		t.Fatalf("second FetchAllModules() error = %v", err)
+	}
+	if got := datasetRequests.Load(); got != 3 {
+		t.Errorf("second fetch made %d dataset requests, want 0", got-3)
+	}
+	if len(modules) != 2 {
+		t.Errorf("got %d modules, want 2", len(modules))
+	}
+	if got := client.GeneratedAt(); got != generatedAt {
+		t.Errorf("GeneratedAt() = %q, want %q", got, generatedAt)
+	}
+}
+
+func TestClient_FetchAllModules_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{CertificateNumber: "1234"}}})
+		case "/api/modules-in-process.json":
+			json.NewEncoder(w).Encode(InProcessModulesResponse{Modules: []InProcessModuleJSON{{ModuleName: "IP"}}})
+		default:
+			http.Error(w, "Error", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+	}
+
+	modules, err := client.FetchAllModules()
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatalf("error = %v, want *FetchError", err)
+	}
+	if failed := fetchErr.Failed(); len(failed) != 1 || failed[0] != DatasetHistorical {
+		t.Errorf("Failed() = %v, want [historical modules]", failed)
+	}
+
+	if len(modules) != 2 {
+		t.Fatalf("got %d modules, want the 2 that loaded", len(modules))
+	}
+	if modules[0].Status != model.StatusActive || modules[1].Status != model.StatusInProcess {
+		t.Errorf("statuses = %v, %v; want Active, In Process", modules[0].Status, modules[1].Status)
+	}
+}
+
+func TestClient_FetchAllModulesContext_Progress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{}, {}}})
+		case "/api/historical-modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{}}})
+		case "/api/modules-in-process.json":
+			json.NewEncoder(w).Encode(InProcessModulesResponse{})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+	}
+
+	var mu sync.Mutex
+	counts := map[Dataset]int{}
+	modules, err := client.FetchAllModulesContext(context.Background(), func(p Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		if p.Err != nil {
+			t.Errorf("progress for %s reported error %v", p.Dataset, p.Err)
+		}
+		counts[p.Dataset] = p.Count
+	})
+	if err != nil {
+		t.Fatalf("FetchAllModulesContext() error = %v", err)
+	}
+	if len(modules) != 3 {
+		t.Errorf("got %d modules, want 3", len(modules))
+	}
+
+	want := map[Dataset]int{DatasetActive: 2, DatasetHistorical: 1, DatasetInProcess: 0}
+	if len(counts) != len(want) {
+		t.Fatalf("got progress for %d datasets, want %d", len(counts), len(want))
+	}
+	for ds, n := range want {
+		if counts[ds] != n {
+			t.Errorf("progress count for %s = %d, want %d", ds, counts[ds], n)
+		}
+	}
+}
+
+func TestClient_FetchAllModulesContext_Cancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.FetchAllModulesContext(ctx, nil)
+		done <- err
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("FetchAllModulesContext() did not return after cancel")
+	}
+}
+
+func TestClient_CachedModules_NoCache(t *testing.T) {
+	client := &Client{baseURL: BaseURL}
+
+	if _, _, err := client.CachedModules(); err != ErrNotCached {
+		t.Errorf("CachedModules() error = %v, want ErrNotCached", err)
+	}
 }
 
 func TestClient_FetchAllModules_Error(t *testing.T) {
@@ -182,8 +496,32 @@ func TestParseDate(t *testing.T) {
 			want:    "0001-01-01",
 		},
 		{
-			name:    "invalid format",
+			name:    "single digit month and day",
+			input:   "7/1/2029",
+			wantErr: false,
+			want:    "2029-07-01",
+		},
+		{
+			name:    "ISO date",
 			input:   "2024-01-15",
+			wantErr: false,
+			want:    "2024-01-15",
+		},
+		{
+			name:    "RFC 3339 timestamp",
+			input:   "2026-09-21T00:00:00Z",
+			wantErr: false,
+			want:    "2026-09-21",
+		},
+		{
+			name:    "long form",
+			input:   " September 21, 2026 ",
+			wantErr: false,
+			want:    "2026-09-21",
+		},
+		{
+			name:    "invalid format",
+			input:   "21.09.2026",
 			wantErr: false, // Returns zero time, not error
 			want:    "0001-01-01",
 		},
@@ -242,6 +580,10 @@ func TestClient_FetchMetadata_Error(t *testing.T) {
 			if err.Error() != tt.wantErr {
 				t.Errorf("error = %q, want %q", err.Error(), tt.wantErr)
 			}
+			var statusErr *HTTPStatusError
+			if !errors.As(err, &statusErr) || statusErr.Code != tt.statusCode {
+				t.Errorf("error = %#v, want an HTTPStatusError with code %d", err, tt.statusCode)
+			}
 		})
 	}
 }
@@ -317,36 +659,47 @@ func containsSubstring(s, substr string) bool {
 
 func TestParseOverallLevel(t *testing.T) {
 	tests := []struct {
-		name  string
-		input interface{}
-		want  int
+		name      string
+		input     interface{}
+		wantLevel int
+		wantRaw   string
 	}{
 		{
-			name:  "float64 input",
-			input: float64(3),
-			want:  3,
+			name:      "float64 input",
+			input:     float64(3),
+			wantLevel: 3,
+			wantRaw:   "3",
+		},
+		{
+			name:      "int input",
+			input:     2,
+			wantLevel: 2,
+			wantRaw:   "2",
 		},
 		{
-			name:  "int input",
-			input: 2,
-			want:  2,
+			name:      "numeric string input",
+			input:     "2",
+			wantLevel: 2,
+			wantRaw:   "2",
 		},
 		{
-			name:  "string input",
-			input: "Tested Configuration(s)",
-			want:  0,
+			name:      "string input",
+			input:     "Tested Configuration(s)",
+			wantLevel: 0,
+			wantRaw:   "Tested Configuration(s)",
 		},
 		{
-			name:  "nil input",
-			input: nil,
-			want:  0,
+			name:      "nil input",
+			input:     nil,
+			wantLevel: 0,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := parseOverallLevel(tt.input); got != tt.want {
-				t.Errorf("parseOverallLevel(%v) = %v, want %v", tt.input, got, tt.want)
+			got := parseOverallLevel(tt.input)
+			if got.Level != tt.wantLevel || got.Raw != tt.wantRaw {
+				t.Errorf("parseOverallLevel(%v) = %+v, want level %d and raw %q", tt.input, got, tt.wantLevel, tt.wantRaw)
 			}
 		})
 	}
@@ -0,0 +1,292 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// CSRCBaseURL is the NIST Computer Security Resource Center site
+const CSRCBaseURL = "https://csrc.nist.gov"
+
+const (
+	csrcProjectPath     = "/projects/cryptographic-module-validation-program"
+	csrcSearchPath      = csrcProjectPath + "/validated-modules/search"
+	csrcInProcessPath   = csrcProjectPath + "/modules-in-process/modules-in-process-list"
+	csrcCertificatePath = csrcProjectPath + "/certificate/"
+)
+
+// CSRCSource is a DataSource that scrapes the CMVP search results and
+// certificate detail pages on csrc.nist.gov directly, for when the JSON
+// mirror lags behind NIST.
+//
+// Search results only carry the summary columns (certificate, vendor, module,
+// type and validation date); FetchModuleContext scrapes the certificate page
+// for the full record.
+type CSRCSource struct {
+	client *Client
+
+	mu        sync.Mutex
+	fetchedAt time.Time
+}
+
+var (
+	_ DataSource        = (*CSRCSource)(nil)
+	_ GeneratedAtSource = (*CSRCSource)(nil)
+	_ SummarySource     = (*CSRCSource)(nil)
+)
+
+// SummarySource is implemented by data sources whose module lists carry only
+// summary fields, so callers should use FetchModuleContext for full records
+type SummarySource interface {
+	SummaryOnly() bool
+}
+
+// NewCSRCSource creates a scraper for csrc.nist.gov. Client options apply to
+// the underlying HTTP requests; WithBaseURL points it at another host, such
+// as a server replaying saved pages. Pages are never cached on disk.
+func NewCSRCSource(opts ...Option) *CSRCSource {
+	opts = append([]Option{WithBaseURL(CSRCBaseURL)}, opts...)
+	opts = append(opts, WithCache(nil))
+	return &CSRCSource{client: NewClient(opts...)}
+}
+
+// SummaryOnly reports that search results lack the certificate detail fields
+func (s *CSRCSource) SummaryOnly() bool {
+	return true
+}
+
+// FetchAllModulesContext scrapes all three module lists in parallel
+func (s *CSRCSource) FetchAllModulesContext(ctx context.Context, progress ProgressFunc) ([]model.Module, error) {
+	modules, err := fetchAll(ctx, progress, s.FetchDatasetContext)
+
+	s.mu.Lock()
+	s.fetchedAt = time.Now().UTC()
+	s.mu.Unlock()
+	return modules, err
+}
+
+// FetchDatasetContext scrapes a single module list
+func (s *CSRCSource) FetchDatasetContext(ctx context.Context, ds Dataset) ([]model.Module, error) {
+	switch ds {
+	case DatasetActive, DatasetHistorical:
+		q := url.Values{
+			"SearchMode":        {"Advanced"},
+			"CertificateStatus": {ds.Status().String()},
+			"ValidationYear":    {"0"},
+		}
+		resp, err := s.client.get(ctx, csrcSearchPath+"?"+q.Encode(), ds.String())
+		if err != nil {
+			return nil, err
+		}
+		return parseCSRCSearchResults(string(resp.body), s.client.baseURL, ds.Status())
+	case DatasetInProcess:
+		resp, err := s.client.get(ctx, csrcInProcessPath, ds.String())
+		if err != nil {
+			return nil, err
+		}
+		return parseCSRCInProcess(string(resp.body))
+	default:
+		return nil, fmt.Errorf("unknown dataset %d", ds)
+	}
+}
+
+// FetchMetadataContext describes the scraped data. CSRC publishes no
+// metadata, so generated_at is the time of the last full fetch.
+func (s *CSRCSource) FetchMetadataContext(ctx context.Context) (*MetadataJSON, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &MetadataJSON{
+		GeneratedAt: s.GeneratedAt(),
+		Source:      s.client.baseURL,
+	}, nil
+}
+
+// FetchModuleContext scrapes the certificate detail page
+func (s *CSRCSource) FetchModuleContext(ctx context.Context, certificateNumber string) (*model.Module, error) {
+	if _, err := strconv.Atoi(certificateNumber); err != nil {
+		return nil, ErrModuleNotFound
+	}
+
+	resp, err := s.client.get(ctx, csrcCertificatePath+certificateNumber, "certificate "+certificateNumber)
+	if err != nil {
+		var statusErr *HTTPStatusError
+		if errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound {
+			return nil, ErrModuleNotFound
+		}
+		return nil, err
+	}
+	return parseCSRCCertificate(string(resp.body), s.client.baseURL+csrcCertificatePath+certificateNumber)
+}
+
+// GeneratedAt returns when the data was last scraped
+func (s *CSRCSource) GeneratedAt() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fetchedAt.IsZero() {
+		return ""
+	}
+	return s.fetchedAt.Format(time.RFC3339)
+}
+
+// parseCSRCSearchResults extracts modules from a validated modules search page
+func parseCSRCSearchResults(doc, baseURL string, status model.ModuleStatus) ([]model.Module, error) {
+	table, ok := findTable(parseTables(doc), "Certificate Number", "Vendor Name", "Module Name")
+	if !ok {
+		return nil, fmt.Errorf("search results table not found")
+	}
+
+	modules := make([]model.Module, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		cert := table.cell(row, "Certificate Number")
+		modules = append(modules, model.Module{
+			CertificateNumber: cert.Text,
+			CertificateURL:    absoluteURL(baseURL, cert.Href),
+			VendorName:        table.cell(row, "Vendor Name").Text,
+			ModuleName:        table.cell(row, "Module Name").Text,
+			ModuleType:        table.cell(row, "Module Type").Text,
+			ValidationDate:    parseDate(firstLine(table.cell(row, "Validation Date").Text)),
+			Status:            status,
+		})
+	}
+	return modules, nil
+}
+
+// parseCSRCInProcess extracts modules from the modules-in-process list
+func parseCSRCInProcess(doc string) ([]model.Module, error) {
+	table, ok := findTable(parseTables(doc), "Module Name", "Vendor Name")
+	if !ok {
+		return nil, fmt.Errorf("modules in process table not found")
+	}
+
+	modules := make([]model.Module, 0, len(table.Rows))
+	for _, row := range table.Rows {
+		modules = append(modules, model.Module{
+			VendorName: table.cell(row, "Vendor Name").Text,
+			ModuleName: table.cell(row, "Module Name").Text,
+			Standard:   table.cell(row, "Standard").Text,
+			Status:     model.StatusInProcess,
+			Stage:      model.ParseProcessStage(table.cell(row, "Status").Text),
+		})
+	}
+	return modules, nil
+}
+
+// csrcCertRe extracts the certificate number from a certificate page URL
+var csrcCertRe = regexp.MustCompile(`/certificate/(\d+)`)
+
+// parseCSRCCertificate extracts the full record from a certificate detail page
+func parseCSRCCertificate(doc, pageURL string) (*model.Module, error) {
+	fields := parseLabeledRows(doc)
+	if fields["module name"] == "" {
+		return nil, fmt.Errorf("certificate details not found")
+	}
+
+	mod := &model.Module{
+		CertificateNumber: fields["certificate number"],
+		CertificateURL:    pageURL,
+		VendorName:        firstLine(fields["vendor"]),
+		ModuleName:        fields["module name"],
+		ModuleType:        fields["module type"],
+		Status:            parseCSRCStatus(fields["status"]),
+		Standard:          fields["standard"],
+		OverallLevel:      model.ParseSecurityLevel(fields["overall level"]),
+		SunsetDate:        parseDate(fields["sunset date"]),
+		Caveat:            fields["caveat"],
+		Embodiment:        fields["embodiment"],
+		Description:       fields["description"],
+	}
+	if mod.CertificateNumber == "" {
+		if m := csrcCertRe.FindStringSubmatch(pageURL); m != nil {
+			mod.CertificateNumber = m[1]
+		}
+	}
+
+	base := pageURL
+	if u, err := url.Parse(pageURL); err == nil {
+		base = u.Scheme + "://" + u.Host
+	}
+	if href := findLink(doc, "Security Policy"); href != "" {
+		mod.SecurityPolicyURL = absoluteURL(base, href)
+	}
+
+	tables := parseTables(doc)
+	if history, ok := findTable(tables, "Date", "Lab"); ok && len(history.Rows) > 0 {
+		// The first row is the initial validation
+		mod.ValidationDate = parseDate(history.cell(history.Rows[0], "Date").Text)
+		mod.Lab = history.cell(history.Rows[len(history.Rows)-1], "Lab").Text
+	}
+	if algos, ok := findTable(tables, "Algorithm"); ok {
+		seen := make(map[string]bool)
+		for _, row := range algos.Rows {
+			name := algos.cell(row, "Algorithm").Text
+			if name == "" {
+				continue
+			}
+			detail := name
+			if cavp := algos.cell(row, "Certificate").Text; cavp != "" {
+				detail = fmt.Sprintf("%s (%s)", name, cavp)
+			}
+			mod.AlgorithmsDetailed = append(mod.AlgorithmsDetailed, detail)
+
+			family := algorithmFamily(name)
+			if !seen[family] {
+				seen[family] = true
+				mod.Algorithms = append(mod.Algorithms, family)
+			}
+		}
+	}
+	return mod, nil
+}
+
+// parseCSRCStatus maps the certificate page status text
+func parseCSRCStatus(s string) model.ModuleStatus {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "historical", "revoked":
+		return model.StatusHistorical
+	default:
+		return model.StatusActive
+	}
+}
+
+// algorithmFamily returns the leading name of an algorithm, e.g. "AES" for "AES-GCM"
+func algorithmFamily(name string) string {
+	if i := strings.IndexAny(name, " -("); i > 0 {
+		return name[:i]
+	}
+	return name
+}
+
+// firstLine returns the first line of multi-line cell text
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// absoluteURL resolves a site-relative link against baseURL
+func absoluteURL(baseURL, href string) string {
+	if href == "" {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil || u.IsAbs() {
+		return href
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return href
+	}
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func readFixture(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "csrc", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestParseCSRCSearchResults(t *testing.T) {
+	modules, err := parseCSRCSearchResults(readFixture(t, "search_active.html"), CSRCBaseURL, model.StatusActive)
+	if err != nil {
+		t.Fatalf("parseCSRCSearchResults() error = %v", err)
+	}
+	if len(modules) != 2 {
+		t.Fatalf("got %d modules, want 2", len(modules))
+	}
+
+	first := modules[0]
+	if first.CertificateNumber != "4282" {
+		t.Errorf("CertificateNumber = %q, want 4282", first.CertificateNumber)
+	}
+	if first.CertificateURL != CSRCBaseURL+"/projects/cryptographic-module-validation-program/certificate/4282" {
+		t.Errorf("CertificateURL = %q", first.CertificateURL)
+	}
+	if first.VendorName != "OpenSSL Software Foundation" || first.ModuleName != "OpenSSL FIPS Provider" {
+		t.Errorf("vendor/module = %q / %q", first.VendorName, first.ModuleName)
+	}
+	if first.ModuleType != "Software" || first.Status != model.StatusActive {
+		t.Errorf("type/status = %q / %v", first.ModuleType, first.Status)
+	}
+	if got := first.ValidationDate.Format("2006-01-02"); got != "2024-07-12" {
+		t.Errorf("ValidationDate = %s, want 2024-07-12", got)
+	}
+
+	second := modules[1]
+	if second.VendorName != "Acme & Sons, Inc." {
+		t.Errorf("VendorName = %q, want entities decoded", second.VendorName)
+	}
+	if second.ModuleName != "Acme Crypto Engine" {
+		t.Errorf("ModuleName = %q, want whitespace collapsed", second.ModuleName)
+	}
+	if got := second.ValidationDate.Format("2006-01-02"); got != "2024-08-01" {
+		t.Errorf("ValidationDate = %s, want the first of several dates", got)
+	}
+}
+
+func TestParseCSRCSearchResults_NoTable(t *testing.T) {
+	if _, err := parseCSRCSearchResults("<html><body>Maintenance</body></html>", CSRCBaseURL, model.StatusActive); err == nil {
+		t.Error("expected error for a page without results table")
+	}
+}
+
+func TestParseCSRCInProcess(t *testing.T) {
+	modules, err := parseCSRCInProcess(readFixture(t, "in_process.html"))
+	if err != nil {
+		t.Fatalf("parseCSRCInProcess() error = %v", err)
+	}
+	if len(modules) != 2 {
+		t.Fatalf("got %d modules, want 2", len(modules))
+	}
+	if modules[0].ModuleName != "Quantum Safe Module" || modules[0].VendorName != "PQ Labs" {
+		t.Errorf("first module = %+v", modules[0])
+	}
+	if modules[0].Status != model.StatusInProcess {
+		t.Errorf("Status = %v, want In Process", modules[0].Status)
+	}
+	if modules[0].Stage != model.StageReviewPending || modules[1].Stage != model.StageInReview {
+		t.Errorf("Stages = %q, %q, want Review Pending, In Review", modules[0].Stage, modules[1].Stage)
+	}
+	if modules[0].Standard != "FIPS 140-3" || modules[0].ModuleType != "" {
+		t.Errorf("expected Standard to be kept in its own field, got %+v", modules[0])
+	}
+}
+
+func TestParseCSRCCertificate(t *testing.T) {
+	pageURL := CSRCBaseURL + "/projects/cryptographic-module-validation-program/certificate/4282"
+	mod, err := parseCSRCCertificate(readFixture(t, "certificate_4282.html"), pageURL)
+	if err != nil {
+		t.Fatalf("parseCSRCCertificate() error = %v", err)
+	}
+
+	checks := []struct {
+		field, got, want string
+	}{
+		{"CertificateNumber", mod.CertificateNumber, "4282"},
+		{"ModuleName", mod.ModuleName, "OpenSSL FIPS Provider"},
+		{"VendorName", mod.VendorName, "OpenSSL Software Foundation"},
+		{"Standard", mod.Standard, "FIPS 140-3"},
+		{"ModuleType", mod.ModuleType, "Software"},
+		{"Embodiment", mod.Embodiment, "Multi-Chip Stand Alone"},
+		{"Lab", mod.Lab, "ACUMEN SECURITY, LLC"},
+		{"SunsetDate", mod.SunsetDate.Format("2006-01-02"), "2029-07-11"},
+		{"SecurityPolicyURL", mod.SecurityPolicyURL, CSRCBaseURL + "/CSRC/media/projects/cryptographic-module-validation-program/documents/security-policies/140sp4282.pdf"},
+		{"ValidationDate", mod.ValidationDate.Format("2006-01-02"), "2024-07-12"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q, want %q", c.field, c.got, c.want)
+		}
+	}
+
+	if !strings.HasPrefix(mod.Caveat, "When operated in approved mode") {
+		t.Errorf("Caveat = %q", mod.Caveat)
+	}
+	if mod.OverallLevel.Level != 1 {
+		t.Errorf("OverallLevel = %v, want 1", mod.OverallLevel)
+	}
+	if mod.Status != model.StatusActive {
+		t.Errorf("Status = %v, want Active", mod.Status)
+	}
+	if len(mod.AlgorithmsDetailed) != 4 || mod.AlgorithmsDetailed[0] != "AES-CBC (A4510)" {
+		t.Errorf("AlgorithmsDetailed = %v", mod.AlgorithmsDetailed)
+	}
+	if strings.Join(mod.Algorithms, ",") != "AES,SHA2,ECDSA" {
+		t.Errorf("Algorithms = %v, want [AES SHA2 ECDSA]", mod.Algorithms)
+	}
+}
+
+func TestCSRCSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == csrcSearchPath && r.URL.Query().Get("CertificateStatus") == "Active":
+			w.Write([]byte(readFixture(t, "search_active.html")))
+		case r.URL.Path == csrcSearchPath:
+			http.Error(w, "Error", http.StatusInternalServerError)
+		case r.URL.Path == csrcInProcessPath:
+			w.Write([]byte(readFixture(t, "in_process.html")))
+		case r.URL.Path == csrcCertificatePath+"4282":
+			w.Write([]byte(readFixture(t, "certificate_4282.html")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	src := NewCSRCSource(WithBaseURL(server.URL), WithRetryPolicy(NoRetry))
+	ctx := context.Background()
+
+	if !src.SummaryOnly() {
+		t.Error("expected search results to be summary only")
+	}
+
+	modules, err := src.FetchAllModulesContext(ctx, nil)
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) || len(fetchErr.Errors) != 1 || fetchErr.Errors[0].Dataset != DatasetHistorical {
+		t.Errorf("error = %v, want historical search to fail", err)
+	}
+	if len(modules) != 4 {
+		t.Errorf("got %d modules, want 2 active and 2 in process", len(modules))
+	}
+	if src.GeneratedAt() == "" {
+		t.Error("expected GeneratedAt() to be set after a fetch")
+	}
+
+	mod, err := src.FetchModuleContext(ctx, "4282")
+	if err != nil {
+		t.Fatalf("FetchModuleContext() error = %v", err)
+	}
+	if mod.Lab != "ACUMEN SECURITY, LLC" {
+		t.Errorf("Lab = %q", mod.Lab)
+	}
+	if !strings.HasPrefix(mod.SecurityPolicyURL, server.URL) {
+		t.Errorf("SecurityPolicyURL = %q, want it resolved against the source host", mod.SecurityPolicyURL)
+	}
+
+	for _, cert := range []string{"9999", "../etc"} {
+		if _, err := src.FetchModuleContext(ctx, cert); !errors.Is(err, ErrModuleNotFound) {
+			t.Errorf("FetchModuleContext(%q) error = %v, want ErrModuleNotFound", cert, err)
+		}
+	}
+}
+
+func TestHTMLText(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"<b>Bold</b> text", "Bold text"},
+		{"Line 1<br/>Line 2", "Line 1\nLine 2"},
+		{"  spread\n   over\n lines ", "spread over lines"},
+		{"A &amp; B&nbsp;C", "A & B C"},
+		{"<ul><li>One</li><li>Two</li></ul>", "One\nTwo"},
+	}
+
+	for _, tt := range tests {
+		if got := htmlText(tt.input); got != tt.want {
+			t.Errorf("htmlText(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
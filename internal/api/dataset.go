@@ -0,0 +1,64 @@
+package api
+
+import "github.com/ethanolivertroy/cmvp-tui/internal/model"
+
+// Dataset identifies one of the module datasets published by the API
+type Dataset int
+
+const (
+	DatasetActive Dataset = iota
+	DatasetHistorical
+	DatasetInProcess
+)
+
+// Datasets lists every dataset in the order FetchAllModules combines them
+var Datasets = []Dataset{DatasetActive, DatasetHistorical, DatasetInProcess}
+
+func (d Dataset) String() string {
+	switch d {
+	case DatasetActive:
+		return "active modules"
+	case DatasetHistorical:
+		return "historical modules"
+	case DatasetInProcess:
+		return "in-process modules"
+	default:
+		return "unknown dataset"
+	}
+}
+
+// Endpoint returns the API path the dataset is served from
+func (d Dataset) Endpoint() string {
+	switch d {
+	case DatasetActive:
+		return ModulesEndpoint
+	case DatasetHistorical:
+		return HistoricalEndpoint
+	case DatasetInProcess:
+		return InProcessEndpoint
+	default:
+		return ""
+	}
+}
+
+// Status returns the module status shared by every module in the dataset
+func (d Dataset) Status() model.ModuleStatus {
+	switch d {
+	case DatasetHistorical:
+		return model.StatusHistorical
+	case DatasetInProcess:
+		return model.StatusInProcess
+	default:
+		return model.StatusActive
+	}
+}
+
+// Progress reports that one dataset has finished loading
+type Progress struct {
+	Dataset Dataset
+	Count   int   // Number of modules loaded
+	Err     error // Non-nil if the dataset failed to load
+}
+
+// ProgressFunc receives per-dataset progress. It may be called concurrently.
+type ProgressFunc func(Progress)
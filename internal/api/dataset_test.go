@@ -0,0 +1,38 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestDataset(t *testing.T) {
+	tests := []struct {
+		dataset  Dataset
+		name     string
+		endpoint string
+		status   model.ModuleStatus
+	}{
+		{DatasetActive, "active modules", ModulesEndpoint, model.StatusActive},
+		{DatasetHistorical, "historical modules", HistoricalEndpoint, model.StatusHistorical},
+		{DatasetInProcess, "in-process modules", InProcessEndpoint, model.StatusInProcess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dataset.String(); got != tt.name {
+				t.Errorf("String() = %q, want %q", got, tt.name)
+			}
+			if got := tt.dataset.Endpoint(); got != tt.endpoint {
+				t.Errorf("Endpoint() = %q, want %q", got, tt.endpoint)
+			}
+			if got := tt.dataset.Status(); got != tt.status {
+				t.Errorf("Status() = %v, want %v", got, tt.status)
+			}
+		})
+	}
+
+	if got := Dataset(99).String(); got != "unknown dataset" {
+		t.Errorf("String() for unknown dataset = %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DatasetError records a dataset that failed to load
+type DatasetError struct {
+	Dataset Dataset
+	Err     error
+}
+
+func (e *DatasetError) Error() string {
+	return fmt.Sprintf("fetching %s: %v", e.Dataset, e.Err)
+}
+
+func (e *DatasetError) Unwrap() error {
+	return e.Err
+}
+
+// HTTPStatusError is returned when an endpoint answers with a status other
+// than 200 OK
+type HTTPStatusError struct {
+	Code int    // HTTP status code
+	Name string // What was being fetched, e.g. "certificate 4282"
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("API returned status %d for %s", e.Code, e.Name)
+}
+
+// SchemaError is returned when a dataset fails to decode. Warnings holds
+// the schema drift found in the payload, which usually names the key whose
+// type changed; its message lists them in place of the opaque JSON error.
+type SchemaError struct {
+	Dataset  Dataset
+	Warnings []SchemaWarning
+	Err      error // The decoding error
+}
+
+func (e *SchemaError) Error() string {
+	if len(e.Warnings) == 0 {
+		return e.Err.Error()
+	}
+	drift := make([]string, len(e.Warnings))
+	for i, w := range e.Warnings {
+		drift[i] = fmt.Sprintf("%s %q", w.Issue, w.Key)
+		if w.Detail != "" {
+			drift[i] += " (" + w.Detail + ")"
+		}
+	}
+	return "schema drift: " + strings.Join(drift, "; ")
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// schemaError wraps a decoding error with the drift found in the payload
+func schemaError(ds Dataset, warnings []SchemaWarning, err error) error {
+	return &SchemaError{Dataset: ds, Warnings: warnings, Err: err}
+}
+
+// FetchError is returned by FetchAllModules when one or more datasets failed.
+// The modules from the datasets that did load are returned alongside it.
+type FetchError struct {
+	Errors []*DatasetError
+}
+
+func (e *FetchError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap lets errors.Is and errors.As inspect each dataset failure
+func (e *FetchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Failed returns the datasets that failed to load
+func (e *FetchError) Failed() []Dataset {
+	failed := make([]Dataset, len(e.Errors))
+	for i, err := range e.Errors {
+		failed[i] = err.Dataset
+	}
+	return failed
+}
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFetchError(t *testing.T) {
+	errDown := errors.New("connection refused")
+	err := error(&FetchError{Errors: []*DatasetError{
+		{Dataset: DatasetHistorical, Err: errDown},
+		{Dataset: DatasetInProcess, Err: errors.New("bad gateway")},
+	}})
+
+	msg := err.Error()
+	for _, want := range []string{"fetching historical modules: connection refused", "fetching in-process modules: bad gateway"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Error() = %q, want to contain %q", msg, want)
+		}
+	}
+
+	if !errors.Is(err, errDown) {
+		t.Error("errors.Is should find a wrapped dataset error")
+	}
+
+	var dsErr *DatasetError
+	if !errors.As(err, &dsErr) || dsErr.Dataset != DatasetHistorical {
+		t.Errorf("errors.As = %v, want the historical DatasetError", dsErr)
+	}
+
+	var fetchErr *FetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatal("errors.As should find the FetchError")
+	}
+	failed := fetchErr.Failed()
+	if len(failed) != 2 || failed[0] != DatasetHistorical || failed[1] != DatasetInProcess {
+		t.Errorf("Failed() = %v, want [historical in-process]", failed)
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Minimal HTML extraction helpers for the CSRC pages. The pages are
+// server-rendered and regular, so a handful of patterns is enough and avoids
+// pulling in a full HTML parser.
+var (
+	tableRe    = regexp.MustCompile(`(?is)<table\b([^>]*)>(.*?)</table>`)
+	rowRe      = regexp.MustCompile(`(?is)<tr\b[^>]*>(.*?)</tr>`)
+	cellRe     = regexp.MustCompile(`(?is)<t([hd])\b[^>]*>(.*?)</t[hd]>`)
+	idAttrRe   = regexp.MustCompile(`(?i)\bid\s*=\s*"([^"]*)"`)
+	hrefRe     = regexp.MustCompile(`(?i)<a\b[^>]*\bhref\s*=\s*"([^"]*)"`)
+	anchorRe   = regexp.MustCompile(`(?is)<a\b[^>]*\bhref\s*=\s*"([^"]*)"[^>]*>(.*?)</a>`)
+	breakRe    = regexp.MustCompile(`(?i)<br\s*/?>|</li>|</p>`)
+	tagRe      = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaceRe    = regexp.MustCompile(`\s+`)
+	scriptRe   = regexp.MustCompile(`(?is)<(script|style)\b.*?</(script|style)>`)
+	labelRowRe = regexp.MustCompile(`(?is)<div\b[^>]*class\s*=\s*"[^"]*\bcol-md-3\b[^"]*"[^>]*>(.*?)</div>\s*<div\b[^>]*class\s*=\s*"[^"]*\bcol-md-9\b[^"]*"[^>]*>(.*?)</div>`)
+)
+
+// htmlCell is a table cell's text and the first link inside it
+type htmlCell struct {
+	Text string
+	Href string
+}
+
+// htmlTable is a parsed <table>
+type htmlTable struct {
+	ID      string
+	Headers []string
+	Rows    [][]htmlCell
+}
+
+// column returns the index of the header matching name, or -1
+func (t htmlTable) column(name string) int {
+	for i, h := range t.Headers {
+		if strings.EqualFold(h, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cell returns the cell of row under the header name
+func (t htmlTable) cell(row []htmlCell, name string) htmlCell {
+	i := t.column(name)
+	if i < 0 || i >= len(row) {
+		return htmlCell{}
+	}
+	return row[i]
+}
+
+// parseTables extracts every table in doc
+func parseTables(doc string) []htmlTable {
+	doc = scriptRe.ReplaceAllString(doc, "")
+
+	var tables []htmlTable
+	for _, tm := range tableRe.FindAllStringSubmatch(doc, -1) {
+		var t htmlTable
+		if id := idAttrRe.FindStringSubmatch(tm[1]); id != nil {
+			t.ID = id[1]
+		}
+		for _, rm := range rowRe.FindAllStringSubmatch(tm[2], -1) {
+			var row []htmlCell
+			header := false
+			for _, cm := range cellRe.FindAllStringSubmatch(rm[1], -1) {
+				if strings.EqualFold(cm[1], "h") {
+					header = true
+				}
+				cell := htmlCell{Text: htmlText(cm[2])}
+				if href := hrefRe.FindStringSubmatch(cm[2]); href != nil {
+					cell.Href = html.UnescapeString(href[1])
+				}
+				row = append(row, cell)
+			}
+			if header && t.Headers == nil {
+				for _, c := range row {
+					t.Headers = append(t.Headers, c.Text)
+				}
+				continue
+			}
+			if len(row) > 0 {
+				t.Rows = append(t.Rows, row)
+			}
+		}
+		tables = append(tables, t)
+	}
+	return tables
+}
+
+// findTable returns the first table that has every one of the given headers
+func findTable(tables []htmlTable, headers ...string) (htmlTable, bool) {
+	for _, t := range tables {
+		ok := true
+		for _, h := range headers {
+			if t.column(h) < 0 {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return t, true
+		}
+	}
+	return htmlTable{}, false
+}
+
+// parseLabeledRows extracts the "label | value" rows used by certificate
+// detail pages, keyed by lower-cased label without a trailing colon
+func parseLabeledRows(doc string) map[string]string {
+	doc = scriptRe.ReplaceAllString(doc, "")
+
+	fields := make(map[string]string)
+	for _, m := range labelRowRe.FindAllStringSubmatch(doc, -1) {
+		label := strings.ToLower(strings.TrimSuffix(htmlText(m[1]), ":"))
+		if _, seen := fields[label]; !seen {
+			fields[label] = htmlText(m[2])
+		}
+	}
+	return fields
+}
+
+// findLink returns the href of the first link whose text contains text
+func findLink(doc, text string) string {
+	for _, m := range anchorRe.FindAllStringSubmatch(doc, -1) {
+		if strings.Contains(strings.ToLower(htmlText(m[2])), strings.ToLower(text)) {
+			return html.UnescapeString(m[1])
+		}
+	}
+	return ""
+}
+
+// htmlText converts an HTML fragment to plain text. Source whitespace is
+// collapsed; <br>, </li> and </p> become line breaks.
+func htmlText(fragment string) string {
+	s := spaceRe.ReplaceAllString(fragment, " ")
+	s = breakRe.ReplaceAllString(s, "\n")
+	s = tagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\u00a0", " "))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
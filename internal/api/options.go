@@ -0,0 +1,107 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithCache caches responses in cache. A nil cache disables caching.
+func WithCache(cache *Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// WithRetryPolicy sets how failed requests are retried
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithBaseURL fetches the datasets from a mirror of the API instead of
+// BaseURL. A file:// URL reads them from a local directory (see WithDirectory).
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if u, err := url.Parse(baseURL); err == nil && u.Scheme == "file" {
+			WithDirectory(fileURLPath(u))(c)
+			return
+		}
+		c.baseURL = strings.TrimSuffix(baseURL, "/")
+		c.dir = ""
+	}
+}
+
+// WithDirectory reads modules.json, historical-modules.json,
+// modules-in-process.json and (optionally) metadata.json from dir instead of
+// the network. Responses from a directory are never cached.
+func WithDirectory(dir string) Option {
+	return func(c *Client) {
+		c.dir = dir
+		c.baseURL = "file://"
+		c.cache = nil
+	}
+}
+
+// WithDataURL picks WithBaseURL or WithDirectory based on the form of raw:
+// http(s):// and file:// URLs, or a path to a local directory
+func WithDataURL(raw string) Option {
+	if u, err := url.Parse(raw); err == nil && (u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "file") {
+		return WithBaseURL(raw)
+	}
+	return WithDirectory(raw)
+}
+
+// WithHTTPClient sends requests with hc instead of a default client
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTimeout sets the timeout for each request attempt
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		// Copy so a client passed to WithHTTPClient is not modified
+		hc := *c.httpClient
+		hc.Timeout = timeout
+		c.httpClient = &hc
+	}
+}
+
+// IsLocalDataURL reports whether raw names a local directory rather than a URL
+func IsLocalDataURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err != nil || (u.Scheme != "http" && u.Scheme != "https")
+}
+
+// LocalDataPath returns the directory a local data URL or path refers to
+func LocalDataPath(raw string) string {
+	if u, err := url.Parse(raw); err == nil && u.Scheme == "file" {
+		return fileURLPath(u)
+	}
+	return raw
+}
+
+// fileURLPath converts a file:// URL to a local path
+func fileURLPath(u *url.URL) string {
+	p := u.Path
+	// file:///C:/data parses with a leading slash before the drive letter
+	if runtime.GOOS == "windows" && len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:]
+	}
+	return filepath.FromSlash(p)
+}
+
+// dirTransport returns a transport that serves file:// requests from dir
+func dirTransport(dir string) http.RoundTripper {
+	return http.NewFileTransportFS(os.DirFS(dir))
+}
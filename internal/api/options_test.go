@@ -0,0 +1,155 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// writeDataDir writes the three dataset files to a temp directory
+func writeDataDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	files := map[string]interface{}{
+		"modules.json": ModulesResponse{Modules: []ModuleJSON{
+			{CertificateNumber: "1234", ModuleName: "Local Module"},
+		}},
+		"historical-modules.json": ModulesResponse{Modules: []ModuleJSON{
+			{CertificateNumber: "99", ModuleName: "Old Module"},
+		}},
+		"modules-in-process.json": InProcessModulesResponse{Modules: []InProcessModuleJSON{
+			{ModuleName: "Pending Module"},
+		}},
+	}
+	for name, v := range files {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestWithDirectory(t *testing.T) {
+	dir := writeDataDir(t)
+	client := NewClient(WithCache(NewCache(t.TempDir())), WithDirectory(dir))
+
+	if client.cache != nil {
+		t.Error("expected directory sources not to be cached")
+	}
+	if client.Source() != dir {
+		t.Errorf("Source() = %q, want %q", client.Source(), dir)
+	}
+
+	modules, err := client.FetchAllModules()
+	if err != nil {
+		t.Fatalf("FetchAllModules() error = %v", err)
+	}
+	if len(modules) != 3 {
+		t.Fatalf("got %d modules, want 3", len(modules))
+	}
+	if modules[0].ModuleName != "Local Module" || modules[2].Status != model.StatusInProcess {
+		t.Errorf("unexpected modules from directory: %+v", modules)
+	}
+
+	// metadata.json is optional in a local directory
+	if _, err := client.FetchMetadata(); err == nil {
+		t.Error("expected FetchMetadata() to fail without metadata.json")
+	}
+}
+
+func TestWithBaseURL_FileURL(t *testing.T) {
+	dir := writeDataDir(t)
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}
+
+	client := NewClient(WithBaseURL(u.String()))
+	if client.dir != dir {
+		t.Errorf("dir = %q, want %q", client.dir, dir)
+	}
+
+	modules, err := client.FetchAllModules()
+	if err != nil {
+		t.Fatalf("FetchAllModules() error = %v", err)
+	}
+	if len(modules) != 3 {
+		t.Errorf("got %d modules, want 3", len(modules))
+	}
+}
+
+func TestWithBaseURL_TrimsSlash(t *testing.T) {
+	client := NewClient(WithBaseURL("https://mirror.example.com/api/"))
+	if client.baseURL != "https://mirror.example.com/api" {
+		t.Errorf("baseURL = %q, want trailing slash trimmed", client.baseURL)
+	}
+}
+
+func TestWithDataURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantDir string
+		wantURL string
+	}{
+		{"https url", "https://mirror.example.com/api", "", "https://mirror.example.com/api"},
+		{"http url", "http://localhost:8080", "", "http://localhost:8080"},
+		{"plain path", "testdata/cmvp", "testdata/cmvp", "file://"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(WithDataURL(tt.raw))
+			if client.dir != tt.wantDir {
+				t.Errorf("dir = %q, want %q", client.dir, tt.wantDir)
+			}
+			if client.baseURL != tt.wantURL {
+				t.Errorf("baseURL = %q, want %q", client.baseURL, tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestIsLocalDataURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://mirror.example.com/api": false,
+		"http://localhost:8080":          false,
+		"file:///srv/cmvp":               true,
+		"/srv/cmvp":                      true,
+		"./data":                         true,
+	}
+	for raw, want := range tests {
+		if got := IsLocalDataURL(raw); got != want {
+			t.Errorf("IsLocalDataURL(%q) = %v, want %v", raw, got, want)
+		}
+	}
+
+	if got := LocalDataPath("file:///srv/cmvp"); got != filepath.FromSlash("/srv/cmvp") {
+		t.Errorf("LocalDataPath(file:///srv/cmvp) = %q", got)
+	}
+}
+
+func TestWithHTTPClientAndTimeout(t *testing.T) {
+	hc := &http.Client{Timeout: time.Minute}
+	client := NewClient(WithHTTPClient(hc), WithTimeout(5*time.Second))
+
+	if client.httpClient.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", client.httpClient.Timeout)
+	}
+	if hc.Timeout != time.Minute {
+		t.Error("WithTimeout() should not modify the client passed to WithHTTPClient")
+	}
+
+	client = NewClient(WithHTTPClient(hc))
+	if client.httpClient != hc {
+		t.Error("WithHTTPClient() was not applied")
+	}
+}
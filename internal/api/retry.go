@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how Client retries failed requests.
+// Requests are retried on transport errors, 429 and 5xx responses.
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first; values below 1 mean 1
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each attempt
+	MaxDelay    time.Duration // Upper bound on any single delay, including Retry-After
+}
+
+// DefaultRetryPolicy is used by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// NoRetry makes a single attempt per request
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+// attempts returns the total number of attempts allowed
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// delay returns how long to wait after the given failed attempt (1-based).
+// A Retry-After header on resp takes precedence over exponential backoff.
+func (p RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return p.cap(d)
+		}
+	}
+
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 {
+		// Shift overflowed; fall back to the cap
+		d = p.MaxDelay
+	}
+	d = p.cap(d)
+	if d <= 0 {
+		return 0
+	}
+
+	// Equal jitter: wait between half and all of the backoff so concurrent
+	// clients don't retry in lockstep
+	half := d / 2
+	return half + rand.N(d-half+1) //nolint:gosec // jitter does not need a CSPRNG
+}
+
+// cap limits d to MaxDelay when one is set
+func (p RetryPolicy) cap(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// shouldRetry reports whether a request that produced resp or err is worth
+// another attempt
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		// The caller gave up; retrying would ignore that
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		d := t.Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
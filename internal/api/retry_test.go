@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fastRetry keeps test retries quick while still exercising backoff
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestClient_Retry_TransientErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+	}{
+		{"server error", http.StatusInternalServerError},
+		{"bad gateway", http.StatusBadGateway},
+		{"rate limited", http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if attempts.Add(1) < 3 {
+					w.Header().Set("Retry-After", "1")
+					http.Error(w, "Error", tt.status)
+					return
+				}
+				json.NewEncoder(w).Encode(MetadataJSON{Source: "NIST CMVP"})
+			}))
+			defer server.Close()
+
+			client := &Client{
+				httpClient: &http.Client{Timeout: 5 * time.Second},
+				baseURL:    server.URL + "/api",
+				retry:      fastRetry,
+			}
+
+			metadata, err := client.FetchMetadata()
+			if err != nil {
+				t.Fatalf("FetchMetadata() error = %v", err)
+			}
+			if metadata.Source != "NIST CMVP" {
+				t.Errorf("Source = %q, want NIST CMVP", metadata.Source)
+			}
+			if got := attempts.Load(); got != 3 {
+				t.Errorf("attempts = %d, want 3", got)
+			}
+		})
+	}
+}
+
+func TestClient_Retry_GivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.Error(w, "Error", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		retry:      fastRetry,
+	}
+
+	_, err := client.FetchMetadata()
+	if err == nil || err.Error() != "API returned status 503 for metadata" {
+		t.Errorf("error = %v, want status 503 error", err)
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClient_Retry_NotOnClientErrors(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		retry:      fastRetry,
+	}
+
+	if _, err := client.FetchMetadata(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestClient_Retry_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close() // Connections are now refused
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    url + "/api",
+		retry:      fastRetry,
+	}
+
+	start := time.Now()
+	if _, err := client.FetchMetadata(); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	// Two backoff waits of at least half of 1ms and 2ms
+	if elapsed := time.Since(start); elapsed < time.Millisecond {
+		t.Errorf("elapsed = %v, expected the client to back off between attempts", elapsed)
+	}
+}
+
+func TestClient_Retry_StopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Error", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+		retry:      RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.FetchMetadataContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt, max := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 400 * time.Millisecond,
+		5: time.Second, // capped
+	} {
+		d := p.delay(attempt, nil)
+		if d < max/2 || d > max {
+			t.Errorf("delay(%d) = %v, want between %v and %v", attempt, d, max/2, max)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"120"}}}
+	if d := p.delay(1, resp); d != time.Second {
+		t.Errorf("delay with Retry-After = %v, want capped to %v", d, time.Second)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"seconds", "30", 30 * time.Second, true},
+		{"http date", "Mon, 15 Jan 2024 10:01:00 GMT", time.Minute, true},
+		{"date in past", "Mon, 15 Jan 2024 09:00:00 GMT", 0, true},
+		{"empty", "", 0, false},
+		{"negative", "-5", 0, false},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseRetryAfter(tt.value, now)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,258 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SchemaIssue classifies a difference between upstream JSON and ModuleJSON
+type SchemaIssue int
+
+const (
+	IssueUnknownKey SchemaIssue = iota
+	IssueMissingKey
+	IssueTypeMismatch
+)
+
+func (i SchemaIssue) String() string {
+	switch i {
+	case IssueUnknownKey:
+		return "unknown key"
+	case IssueMissingKey:
+		return "missing key"
+	case IssueTypeMismatch:
+		return "type mismatch"
+	default:
+		return "unknown issue"
+	}
+}
+
+// SchemaWarning reports upstream drift: a key this client does not know, a
+// required key that is absent, or a value of an unexpected JSON type.
+// Warnings are aggregated per dataset, issue and key.
+type SchemaWarning struct {
+	Dataset Dataset
+	Issue   SchemaIssue
+	Key     string
+	Detail  string // e.g. "expected string, got number"
+	Count   int    // Number of records affected
+	Example string // Certificate or module name of the first affected record
+}
+
+func (w SchemaWarning) String() string {
+	s := fmt.Sprintf("%s: %s %q", w.Dataset, w.Issue, w.Key)
+	if w.Detail != "" {
+		s += " (" + w.Detail + ")"
+	}
+	return fmt.Sprintf("%s in %d record(s)", s, w.Count)
+}
+
+// schemaField is the expected shape of one JSON key
+type schemaField struct {
+	required bool
+	types    []string // Accepted JSON types
+}
+
+// moduleSchema and inProcessSchema are derived from the struct tags
+var (
+	moduleSchema    = buildSchema(reflect.TypeOf(ModuleJSON{}))
+	inProcessSchema = buildSchema(reflect.TypeOf(InProcessModuleJSON{}))
+)
+
+// buildSchema reads the json and schema tags of a record struct
+func buildSchema(t reflect.Type) map[string]schemaField {
+	schema := make(map[string]schemaField)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		field := schemaField{types: []string{jsonTypeOf(f.Type)}}
+		for _, opt := range strings.Split(f.Tag.Get("schema"), ",") {
+			switch {
+			case opt == "required":
+				field.required = true
+			case strings.HasPrefix(opt, "type="):
+				field.types = strings.Split(strings.TrimPrefix(opt, "type="), "|")
+			}
+		}
+		schema[name] = field
+	}
+	return schema
+}
+
+// jsonTypeOf names the JSON type a Go type decodes from
+func jsonTypeOf(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return "number"
+	case reflect.Slice:
+		return "array of " + jsonTypeOf(t.Elem()) + "s"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	default:
+		return "any"
+	}
+}
+
+// valueType names the type of a raw JSON value, including the element type
+// of arrays whose elements all share one
+func valueType(raw json.RawMessage) string {
+	t := jsonType(raw)
+	if t != "array" {
+		return t
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil || len(items) == 0 {
+		return t
+	}
+	elem := jsonType(items[0])
+	for _, item := range items[1:] {
+		if jsonType(item) != elem {
+			return t
+		}
+	}
+	return "array of " + elem + "s"
+}
+
+// jsonType names the top-level type of a raw JSON value
+func jsonType(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if s == "" {
+		return "null"
+	}
+	switch s[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	default:
+		return "number"
+	}
+}
+
+// ValidateDataset checks a raw dataset payload against ModuleJSON (or
+// InProcessModuleJSON) and returns any drift it finds, sorted by issue and key
+func ValidateDataset(ds Dataset, body []byte) ([]SchemaWarning, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+
+	rawModules, ok := envelope["modules"]
+	if !ok {
+		return []SchemaWarning{{Dataset: ds, Issue: IssueMissingKey, Key: "modules", Count: 1}}, nil
+	}
+	var records []map[string]json.RawMessage
+	if err := json.Unmarshal(rawModules, &records); err != nil {
+		return []SchemaWarning{{
+			Dataset: ds,
+			Issue:   IssueTypeMismatch,
+			Key:     "modules",
+			Detail:  "expected array of objects, got " + jsonType(rawModules),
+			Count:   1,
+		}}, nil
+	}
+
+	schema := moduleSchema
+	if ds == DatasetInProcess {
+		schema = inProcessSchema
+	}
+
+	found := make(map[string]*SchemaWarning)
+	report := func(issue SchemaIssue, key, detail string, record map[string]json.RawMessage) {
+		id := fmt.Sprintf("%d|%s|%s", issue, key, detail)
+		if w, ok := found[id]; ok {
+			w.Count++
+			return
+		}
+		found[id] = &SchemaWarning{
+			Dataset: ds,
+			Issue:   issue,
+			Key:     key,
+			Detail:  detail,
+			Count:   1,
+			Example: recordName(record),
+		}
+	}
+
+	for _, record := range records {
+		for key, value := range record {
+			field, known := schema[key]
+			if !known {
+				report(IssueUnknownKey, key, "", record)
+				continue
+			}
+			if got := valueType(value); got != "null" && !acceptsType(field.types, value) {
+				report(IssueTypeMismatch, key, fmt.Sprintf("expected %s, got %s", strings.Join(field.types, " or "), got), record)
+			}
+		}
+		for key, field := range schema {
+			if _, ok := record[key]; field.required && !ok {
+				report(IssueMissingKey, key, "", record)
+			}
+		}
+	}
+
+	warnings := make([]SchemaWarning, 0, len(found))
+	for _, w := range found {
+		warnings = append(warnings, *w)
+	}
+	sort.Slice(warnings, func(i, j int) bool {
+		if warnings[i].Issue != warnings[j].Issue {
+			return warnings[i].Issue < warnings[j].Issue
+		}
+		if warnings[i].Key != warnings[j].Key {
+			return warnings[i].Key < warnings[j].Key
+		}
+		return warnings[i].Detail < warnings[j].Detail
+	})
+	return warnings, nil
+}
+
+// acceptsType reports whether value matches one of the accepted JSON types.
+// An empty array is accepted wherever an array is expected.
+func acceptsType(types []string, value json.RawMessage) bool {
+	got := valueType(value)
+	for _, t := range types {
+		if t == "any" || t == got {
+			return true
+		}
+		if got == "array" && strings.HasPrefix(t, "array") && isEmptyArray(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmptyArray(value json.RawMessage) bool {
+	var items []json.RawMessage
+	return json.Unmarshal(value, &items) == nil && len(items) == 0
+}
+
+// recordName identifies a record in warnings
+func recordName(record map[string]json.RawMessage) string {
+	for _, key := range []string{"Certificate Number", "Module Name"} {
+		var s string
+		if err := json.Unmarshal(record[key], &s); err == nil && s != "" {
+			return s
+		}
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateDataset(t *testing.T) {
+	body := `{"metadata": {}, "modules": [
+		{"Certificate Number": "1", "Vendor Name": "A", "Module Name": "M", "Module Type": "Software",
+		 "Validation Date": "01/02/2024", "overall_level": 2, "algorithms": ["AES"], "Lab Name": "X"},
+		{"Certificate Number": "2", "Vendor Name": "B", "Module Name": "N", "Module Type": "Hardware",
+		 "overall_level": "Tested Configuration(s)", "algorithms": "AES", "Lab Name": "Y"},
+		{"Certificate Number": "3", "Vendor Name": "C", "Module Name": "O", "Module Type": "Hardware",
+		 "Validation Date": null, "overall_level": {"level": 1}, "algorithms": [1], "algorithms_detailed": []}
+	]}`
+
+	warnings, err := ValidateDataset(DatasetActive, []byte(body))
+	if err != nil {
+		t.Fatalf("ValidateDataset() error = %v", err)
+	}
+
+	want := []SchemaWarning{
+		{Dataset: DatasetActive, Issue: IssueUnknownKey, Key: "Lab Name", Count: 2, Example: "1"},
+		{Dataset: DatasetActive, Issue: IssueMissingKey, Key: "Validation Date", Count: 1, Example: "2"},
+		{Dataset: DatasetActive, Issue: IssueTypeMismatch, Key: "algorithms", Detail: "expected array of strings, got array of numbers", Count: 1, Example: "3"},
+		{Dataset: DatasetActive, Issue: IssueTypeMismatch, Key: "algorithms", Detail: "expected array of strings, got string", Count: 1, Example: "2"},
+		{Dataset: DatasetActive, Issue: IssueTypeMismatch, Key: "overall_level", Detail: "expected number or string, got object", Count: 1, Example: "3"},
+	}
+	if len(warnings) != len(want) {
+		t.Fatalf("got %d warnings, want %d: %+v", len(warnings), len(want), warnings)
+	}
+	for i := range want {
+		if warnings[i] != want[i] {
+			t.Errorf("warnings[%d] = %+v, want %+v", i, warnings[i], want[i])
+		}
+	}
+}
+
+func TestValidateDataset_InProcess(t *testing.T) {
+	body := `{"modules": [{"Module Name": "M", "Vendor Name": "V", "Standard": "FIPS 140-3"}]}`
+
+	warnings, err := ValidateDataset(DatasetInProcess, []byte(body))
+	if err != nil {
+		t.Fatalf("ValidateDataset() error = %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Issue != IssueMissingKey || warnings[0].Key != "Status" {
+		t.Errorf("expected a missing Status key, got %+v", warnings)
+	}
+	if warnings[0].Example != "M" {
+		t.Errorf("Example = %q, want the module name", warnings[0].Example)
+	}
+}
+
+func TestValidateDataset_Envelope(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		issue SchemaIssue
+	}{
+		{"renamed modules key", `{"data": []}`, IssueMissingKey},
+		{"modules not an array", `{"modules": {}}`, IssueTypeMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := ValidateDataset(DatasetActive, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("ValidateDataset() error = %v", err)
+			}
+			if len(warnings) != 1 || warnings[0].Issue != tt.issue || warnings[0].Key != "modules" {
+				t.Errorf("got %+v, want one %s on modules", warnings, tt.issue)
+			}
+		})
+	}
+
+	if _, err := ValidateDataset(DatasetActive, []byte("not json")); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestSchemaWarning_String(t *testing.T) {
+	w := SchemaWarning{Dataset: DatasetHistorical, Issue: IssueTypeMismatch, Key: "lab", Detail: "expected string, got number", Count: 3}
+	want := `historical modules: type mismatch "lab" (expected string, got number) in 3 record(s)`
+	if got := w.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestClient_SchemaWarnings(t *testing.T) {
+	dir := writeDataDir(t)
+	drifted, err := json.Marshal(map[string]interface{}{
+		"modules": []map[string]interface{}{{"Certificate Number": "1234", "Vendor": "renamed"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "modules.json"), drifted, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(WithDirectory(dir))
+	if _, err := client.FetchAllModules(); err != nil {
+		t.Fatalf("FetchAllModules() error = %v", err)
+	}
+
+	var unknown, missing int
+	for _, w := range client.SchemaWarnings() {
+		if w.Dataset != DatasetActive {
+			t.Errorf("unexpected warning for %s: %v", w.Dataset, w)
+		}
+		switch w.Issue {
+		case IssueUnknownKey:
+			unknown++
+		case IssueMissingKey:
+			missing++
+		}
+	}
+	if unknown != 1 || missing != 4 {
+		t.Errorf("got %d unknown and %d missing keys, want 1 and 4: %v", unknown, missing, client.SchemaWarnings())
+	}
+}
+
+func TestClient_SchemaWarnings_DecodeFailure(t *testing.T) {
+	dir := writeDataDir(t)
+	drifted := `{"modules": [{"Certificate Number": "1234", "Vendor Name": "V", "Module Name": "M", "Module Type": "Software",
+		"Validation Date": "01/02/2024", "algorithms": {"AES": true}}]}`
+	if err := os.WriteFile(filepath.Join(dir, "modules.json"), []byte(drifted), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(WithDirectory(dir))
+	_, err := client.FetchAllModules()
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("FetchAllModules() error = %v, want a SchemaError", err)
+	}
+	if schemaErr.Dataset != DatasetActive || len(schemaErr.Warnings) != 1 || schemaErr.Warnings[0].Key != "algorithms" {
+		t.Errorf("SchemaError = %+v, want the algorithms type mismatch", schemaErr)
+	}
+	if want := `schema drift: type mismatch "algorithms" (expected array of strings, got object)`; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not name the drifted key", err)
+	}
+
+	warnings := client.SchemaWarnings()
+	if len(warnings) != 1 || warnings[0].Issue != IssueTypeMismatch {
+		t.Errorf("SchemaWarnings() = %v, want the algorithms type mismatch", warnings)
+	}
+}
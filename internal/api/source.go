@@ -0,0 +1,204 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// ErrModuleNotFound is returned when no module has the requested certificate number
+var ErrModuleNotFound = errors.New("module not found")
+
+// DataSource provides CMVP module data. Client is the default implementation;
+// MemorySource serves fixed data for tests and embedding applications.
+type DataSource interface {
+	// FetchAllModulesContext returns every module in Datasets order. When some
+	// datasets fail it returns the rest along with a *FetchError.
+	FetchAllModulesContext(ctx context.Context, progress ProgressFunc) ([]model.Module, error)
+
+	// FetchDatasetContext returns the modules of a single dataset
+	FetchDatasetContext(ctx context.Context, ds Dataset) ([]model.Module, error)
+
+	// FetchMetadataContext returns metadata describing the data set
+	FetchMetadataContext(ctx context.Context) (*MetadataJSON, error)
+
+	// FetchModuleContext returns the full record for a certificate number,
+	// or ErrModuleNotFound
+	FetchModuleContext(ctx context.Context, certificateNumber string) (*model.Module, error)
+}
+
+// CachedSource is implemented by data sources that keep an offline copy of
+// their data which can be shown before a fetch completes
+type CachedSource interface {
+	// CachedModules returns the cached modules and their generated_at tag,
+	// or ErrNotCached
+	CachedModules() ([]model.Module, string, error)
+}
+
+// GeneratedAtSource is implemented by data sources that know when the data
+// returned by their last fetch was generated
+type GeneratedAtSource interface {
+	GeneratedAt() string
+}
+
+// SchemaReporter is implemented by data sources that validate upstream
+// payloads against the schema they expect
+type SchemaReporter interface {
+	// SchemaWarnings returns the drift found in the most recent fetch
+	SchemaWarnings() []SchemaWarning
+}
+
+var (
+	_ DataSource        = (*Client)(nil)
+	_ SchemaReporter    = (*Client)(nil)
+	_ CachedSource      = (*Client)(nil)
+	_ GeneratedAtSource = (*Client)(nil)
+	_ DataSource        = (*MemorySource)(nil)
+	_ GeneratedAtSource = (*MemorySource)(nil)
+)
+
+// FetchModuleContext returns the active or historical module with the given
+// certificate number. It looks in the datasets the client last fetched or
+// read from its cache and only fetches a dataset that has not been loaded
+// yet. A dataset that fails to load is skipped; its error is returned only
+// if the certificate is not found in the other.
+func (c *Client) FetchModuleContext(ctx context.Context, certificateNumber string) (*model.Module, error) {
+	if certificateNumber == "" {
+		return nil, ErrModuleNotFound
+	}
+
+	var fetchErr error
+	for _, ds := range []Dataset{DatasetActive, DatasetHistorical} {
+		byCert, ok := c.fetchedByCert(ds)
+		if !ok {
+			if _, err := c.FetchDatasetContext(ctx, ds); err != nil {
+				if fetchErr == nil {
+					fetchErr = err
+				}
+				continue
+			}
+			byCert, _ = c.fetchedByCert(ds)
+		}
+		if mod, ok := byCert[certificateNumber]; ok {
+			return &mod, nil
+		}
+	}
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	return nil, ErrModuleNotFound
+}
+
+// fetchedByCert returns the last fetch of ds indexed by certificate number
+func (c *Client) fetchedByCert(ds Dataset) (map[string]model.Module, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	byCert, ok := c.byCert[ds]
+	return byCert, ok
+}
+
+// setByCert records modules as the contents of ds for FetchModuleContext.
+// Unless replace is set, an index that is already there is kept, so a late
+// cache read does not hide fresher downloaded data.
+func (c *Client) setByCert(ds Dataset, modules []model.Module, replace bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.byCert[ds]; ok && !replace {
+		return
+	}
+	if c.byCert == nil {
+		c.byCert = make(map[Dataset]map[string]model.Module)
+	}
+	c.byCert[ds] = indexByCert(modules)
+}
+
+// indexByCert maps certificate numbers to their modules
+func indexByCert(modules []model.Module) map[string]model.Module {
+	byCert := make(map[string]model.Module, len(modules))
+	for _, mod := range modules {
+		if mod.CertificateNumber != "" {
+			byCert[mod.CertificateNumber] = mod
+		}
+	}
+	return byCert
+}
+
+// MemorySource is a DataSource backed by modules held in memory
+type MemorySource struct {
+	Modules  []model.Module
+	Metadata MetadataJSON
+}
+
+// NewMemorySource creates a data source serving modules and metadata
+func NewMemorySource(modules []model.Module, metadata MetadataJSON) *MemorySource {
+	return &MemorySource{Modules: modules, Metadata: metadata}
+}
+
+// FetchAllModulesContext returns every module grouped in Datasets order
+func (s *MemorySource) FetchAllModulesContext(ctx context.Context, progress ProgressFunc) ([]model.Module, error) {
+	var allModules []model.Module
+	for _, ds := range Datasets {
+		modules, err := s.FetchDatasetContext(ctx, ds)
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(Progress{Dataset: ds, Count: len(modules)})
+		}
+		allModules = append(allModules, modules...)
+	}
+	return allModules, nil
+}
+
+// FetchDatasetContext returns the modules whose status matches ds
+func (s *MemorySource) FetchDatasetContext(ctx context.Context, ds Dataset) ([]model.Module, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var modules []model.Module
+	for _, mod := range s.Modules {
+		if mod.Status == ds.Status() {
+			modules = append(modules, mod)
+		}
+	}
+	return modules, nil
+}
+
+// FetchMetadataContext returns the source's metadata
+func (s *MemorySource) FetchMetadataContext(ctx context.Context) (*MetadataJSON, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	metadata := s.Metadata
+	return &metadata, nil
+}
+
+// FetchModuleContext returns the module with the given certificate number
+func (s *MemorySource) FetchModuleContext(ctx context.Context, certificateNumber string) (*model.Module, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if mod := findModule(s.Modules, certificateNumber); mod != nil {
+		return mod, nil
+	}
+	return nil, ErrModuleNotFound
+}
+
+// GeneratedAt returns the generated_at timestamp from the source's metadata
+func (s *MemorySource) GeneratedAt() string {
+	return s.Metadata.GeneratedAt
+}
+
+// findModule returns a copy of the module with the given certificate number
+func findModule(modules []model.Module, certificateNumber string) *model.Module {
+	if certificateNumber == "" {
+		return nil
+	}
+	for _, mod := range modules {
+		if mod.CertificateNumber == certificateNumber {
+			return &mod
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestClient_FetchModuleContext(t *testing.T) {
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{CertificateNumber: "1234", ModuleName: "Active"}}})
+		case "/api/historical-modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{CertificateNumber: "99", ModuleName: "Old"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+	}
+
+	mod, err := client.FetchModuleContext(context.Background(), "99")
+	if err != nil {
+		t.Fatalf("FetchModuleContext() error = %v", err)
+	}
+	if mod.ModuleName != "Old" || mod.Status != model.StatusHistorical {
+		t.Errorf("got %q (%v), want historical module Old", mod.ModuleName, mod.Status)
+	}
+
+	if _, err := client.FetchModuleContext(context.Background(), "5555"); !errors.Is(err, ErrModuleNotFound) {
+		t.Errorf("error = %v, want ErrModuleNotFound", err)
+	}
+
+	// Later lookups use the datasets already fetched
+	if mod, err := client.FetchModuleContext(context.Background(), "1234"); err != nil || mod.ModuleName != "Active" {
+		t.Errorf("FetchModuleContext(1234) = %+v, %v", mod, err)
+	}
+	for _, path := range []string{"/api/modules.json", "/api/historical-modules.json"} {
+		if requests[path] != 1 {
+			t.Errorf("%s fetched %d times, want once", path, requests[path])
+		}
+	}
+}
+
+func TestMemorySource(t *testing.T) {
+	src := NewMemorySource([]model.Module{
+		{CertificateNumber: "2", ModuleName: "Historical", Status: model.StatusHistorical},
+		{ModuleName: "Pending", Status: model.StatusInProcess},
+		{CertificateNumber: "1", ModuleName: "Active", Status: model.StatusActive},
+	}, MetadataJSON{GeneratedAt: "2024-01-15T10:00:00Z"})
+	ctx := context.Background()
+
+	var progress []Dataset
+	modules, err := src.FetchAllModulesContext(ctx, func(p Progress) {
+		progress = append(progress, p.Dataset)
+	})
+	if err != nil {
+		t.Fatalf("FetchAllModulesContext() error = %v", err)
+	}
+	if len(modules) != 3 || modules[0].ModuleName != "Active" || modules[2].ModuleName != "Pending" {
+		t.Errorf("modules not grouped in dataset order: %+v", modules)
+	}
+	if len(progress) != len(Datasets) {
+		t.Errorf("got progress for %d datasets, want %d", len(progress), len(Datasets))
+	}
+
+	historical, err := src.FetchDatasetContext(ctx, DatasetHistorical)
+	if err != nil || len(historical) != 1 {
+		t.Errorf("FetchDatasetContext(historical) = %d modules, %v; want 1, nil", len(historical), err)
+	}
+
+	metadata, err := src.FetchMetadataContext(ctx)
+	if err != nil || metadata.GeneratedAt != "2024-01-15T10:00:00Z" {
+		t.Errorf("FetchMetadataContext() = %+v, %v", metadata, err)
+	}
+	if src.GeneratedAt() != "2024-01-15T10:00:00Z" {
+		t.Errorf("GeneratedAt() = %q", src.GeneratedAt())
+	}
+
+	mod, err := src.FetchModuleContext(ctx, "2")
+	if err != nil || mod.ModuleName != "Historical" {
+		t.Errorf("FetchModuleContext(2) = %+v, %v", mod, err)
+	}
+	if _, err := src.FetchModuleContext(ctx, ""); !errors.Is(err, ErrModuleNotFound) {
+		t.Errorf("FetchModuleContext(\"\") error = %v, want ErrModuleNotFound", err)
+	}
+}
+
+func TestMemorySource_Canceled(t *testing.T) {
+	src := NewMemorySource(nil, MetadataJSON{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := src.FetchAllModulesContext(ctx, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want context.Canceled", err)
+	}
+}
+
+func TestClient_FetchModuleContext_PartialFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/historical-modules.json" {
+			http.Error(w, "down", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{CertificateNumber: "99", ModuleName: "Old"}}})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		baseURL:    server.URL + "/api",
+	}
+
+	// The active dataset failing does not hide historical certificates
+	if mod, err := client.FetchModuleContext(context.Background(), "99"); err != nil || mod.ModuleName != "Old" {
+		t.Errorf("FetchModuleContext(99) = %+v, %v; want historical module Old", mod, err)
+	}
+
+	// A certificate found nowhere reports the failure, not ErrModuleNotFound
+	var statusErr *HTTPStatusError
+	if _, err := client.FetchModuleContext(context.Background(), "1234"); !errors.As(err, &statusErr) {
+		t.Errorf("error = %v, want the active dataset's HTTPStatusError", err)
+	}
+}
+
+func TestClient_FetchModuleContext_Cached(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{CertificateNumber: "1234", ModuleName: "Active"}}})
+		case "/api/historical-modules.json":
+			json.NewEncoder(w).Encode(ModulesResponse{Modules: []ModuleJSON{{CertificateNumber: "99", ModuleName: "Old"}}})
+		default:
+			json.NewEncoder(w).Encode(InProcessModulesResponse{})
+		}
+	}))
+	defer server.Close()
+
+	cache := NewCache(t.TempDir())
+	newClient := func() *Client {
+		return &Client{httpClient: &http.Client{Timeout: 5 * time.Second}, baseURL: server.URL + "/api", cache: cache}
+	}
+	if _, err := newClient().FetchAllModules(); err != nil {
+		t.Fatal(err)
+	}
+
+	// A client started from the cache looks certificates up without downloading
+	client := newClient()
+	if _, _, err := client.CachedModules(); err != nil {
+		t.Fatal(err)
+	}
+	requests = 0
+	if mod, err := client.FetchModuleContext(context.Background(), "99"); err != nil || mod.ModuleName != "Old" {
+		t.Errorf("FetchModuleContext(99) = %+v, %v", mod, err)
+	}
+	if _, err := client.FetchModuleContext(context.Background(), ""); !errors.Is(err, ErrModuleNotFound) {
+		t.Errorf("empty certificate error = %v, want ErrModuleNotFound", err)
+	}
+	if requests != 0 {
+		t.Errorf("made %d requests, want the cached datasets to be used", requests)
+	}
+}
@@ -2,35 +2,38 @@ package api
 
 // ModuleJSON represents the JSON structure from the API
 // Used for active and historical modules
+//
+// The schema tag drives ValidateDataset: "required" keys must be present and
+// "type=" lists the JSON types accepted where Go's type allows several.
 type ModuleJSON struct {
-	CertificateNumber    string `json:"Certificate Number"`
+	CertificateNumber    string `json:"Certificate Number" schema:"required"`
 	CertificateNumberURL string `json:"Certificate Number_url"`
-	VendorName           string `json:"Vendor Name"`
-	ModuleName           string `json:"Module Name"`
-	ModuleType           string `json:"Module Type"`
-	ValidationDate       string `json:"Validation Date"`
+	VendorName           string `json:"Vendor Name" schema:"required"`
+	ModuleName           string `json:"Module Name" schema:"required"`
+	ModuleType           string `json:"Module Type" schema:"required"`
+	ValidationDate       string `json:"Validation Date" schema:"required"`
 
 	// Extended fields from certificate detail extraction
-	Standard           string   `json:"standard"`
-	Status             string   `json:"status"`
-	OverallLevel       interface{} `json:"overall_level"` // Can be int or string from API
-	SunsetDate         string   `json:"sunset_date"`
-	Caveat             string   `json:"caveat"`
-	Embodiment         string   `json:"embodiment"`
-	Description        string   `json:"description"`
-	Lab                string   `json:"lab"`
-	Algorithms         []string `json:"algorithms"`
-	AlgorithmsDetailed []string `json:"algorithms_detailed"`
-	SecurityPolicyURL  string   `json:"security_policy_url"`
-	CertificateDetailURL string `json:"certificate_detail_url"`
+	Standard             string      `json:"standard"`
+	Status               string      `json:"status"`
+	OverallLevel         interface{} `json:"overall_level" schema:"type=number|string"` // Can be int or string from API
+	SunsetDate           string      `json:"sunset_date"`
+	Caveat               string      `json:"caveat"`
+	Embodiment           string      `json:"embodiment"`
+	Description          string      `json:"description"`
+	Lab                  string      `json:"lab"`
+	Algorithms           []string    `json:"algorithms"`
+	AlgorithmsDetailed   []string    `json:"algorithms_detailed"`
+	SecurityPolicyURL    string      `json:"security_policy_url"`
+	CertificateDetailURL string      `json:"certificate_detail_url"`
 }
 
 // InProcessModuleJSON has slightly different structure for modules in process
 type InProcessModuleJSON struct {
-	ModuleName string `json:"Module Name"`
-	VendorName string `json:"Vendor Name"`
-	Standard   string `json:"Standard"`
-	Status     string `json:"Status"`
+	ModuleName string `json:"Module Name" schema:"required"`
+	VendorName string `json:"Vendor Name" schema:"required"`
+	Standard   string `json:"Standard" schema:"required"`
+	Status     string `json:"Status" schema:"required"`
 }
 
 // MetadataJSON represents the metadata endpoint response
@@ -0,0 +1,115 @@
+// Package cli implements the non-interactive cmvp subcommands
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// Exit codes shared by the subcommands
+const (
+	ExitOK       = 0
+	ExitFailure  = 1 // The command ran but found problems or no results
+	ExitError    = 2 // Bad usage or the data could not be loaded
+	ExitNotFound = 3 // The requested certificate does not exist
+)
+
+// Command runs a subcommand with its arguments and returns its exit code
+type Command func(ctx context.Context, args []string, stdout, stderr io.Writer) int
+
+// commands maps subcommand names to their implementations
+var commands = map[string]Command{
+	"diff":     Diff,
+	"doctor":   Doctor,
+	"pqc":      PQC,
+	"report":   Report,
+	"search":   Search,
+	"show":     Show,
+	"snapshot": Snapshot,
+}
+
+// Lookup returns the subcommand called name
+func Lookup(name string) (Command, bool) {
+	cmd, ok := commands[name]
+	return cmd, ok
+}
+
+// SourceFlags are the data source flags shared by the TUI and subcommands
+type SourceFlags struct {
+	DataURL string
+	Source  string
+	Timeout time.Duration
+}
+
+// Register adds the source flags to fs
+func (f *SourceFlags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.DataURL, "data-url", "", "Base URL of a NIST-CMVP-API mirror, or a file:// URL or directory containing the JSON datasets")
+	fs.DurationVar(&f.Timeout, "timeout", 30*time.Second, "Timeout for each API request")
+	fs.StringVar(&f.Source, "source", "mirror", "Data source: mirror (NIST-CMVP-API JSON) or csrc (scrape csrc.nist.gov)")
+}
+
+// NewSource builds the data source the flags describe
+func (f *SourceFlags) NewSource() (api.DataSource, error) {
+	opts := []api.Option{api.WithTimeout(f.Timeout)}
+	if f.DataURL != "" {
+		if api.IsLocalDataURL(f.DataURL) {
+			if info, err := os.Stat(api.LocalDataPath(f.DataURL)); err != nil || !info.IsDir() {
+				return nil, fmt.Errorf("--data-url %q is not a URL or a directory", f.DataURL)
+			}
+		}
+		opts = append(opts, api.WithDataURL(f.DataURL))
+	}
+
+	switch f.Source {
+	case "mirror":
+		return api.NewClient(opts...), nil
+	case "csrc":
+		return api.NewCSRCSource(opts...), nil
+	default:
+		return nil, fmt.Errorf("unknown --source %q (want mirror or csrc)", f.Source)
+	}
+}
+
+// loadModules fetches every dataset from the source the flags describe.
+// Datasets that fail to load are reported on stderr and skipped; ok is false
+// if nothing could be loaded.
+func (f *SourceFlags) loadModules(ctx context.Context, stderr io.Writer) (modules []model.Module, source api.DataSource, ok bool) {
+	source, err := f.NewSource()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return nil, nil, false
+	}
+	modules, err = source.FetchAllModulesContext(ctx, nil)
+	var fetchErr *api.FetchError
+	switch {
+	case errors.As(err, &fetchErr) && len(modules) > 0:
+		for _, dsErr := range fetchErr.Errors {
+			fmt.Fprintf(stderr, "Warning: %v\n", dsErr)
+		}
+	case err != nil:
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return nil, nil, false
+	}
+	return modules, source, true
+}
+
+// parseFlags parses args into fs, reporting errors on stderr. ok is false
+// when the command should exit with code.
+func parseFlags(fs *flag.FlagSet, args []string, stderr io.Writer) (code int, ok bool) {
+	fs.SetOutput(stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK, false
+		}
+		return ExitError, false
+	}
+	return ExitOK, true
+}
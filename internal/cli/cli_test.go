@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+)
+
+// writeDataDir writes the three dataset files to a temp directory
+func writeDataDir(t *testing.T, active string) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"modules.json":            active,
+		"historical-modules.json": `{"modules": []}`,
+		"modules-in-process.json": `{"modules": [{"Module Name": "Pending", "Vendor Name": "V", "Standard": "FIPS 140-3", "Status": "In Review"}]}`,
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLookup(t *testing.T) {
+	if _, ok := Lookup("doctor"); !ok {
+		t.Error("expected doctor to be a command")
+	}
+	if _, ok := Lookup("frobnicate"); ok {
+		t.Error("expected unknown commands not to be found")
+	}
+}
+
+func TestSourceFlags(t *testing.T) {
+	var sf SourceFlags
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	sf.Register(fs)
+	if err := fs.Parse([]string{"--timeout", "5s", "--source", "csrc"}); err != nil {
+		t.Fatal(err)
+	}
+	if sf.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, want 5s", sf.Timeout)
+	}
+
+	source, err := sf.NewSource()
+	if err != nil {
+		t.Fatalf("NewSource() error = %v", err)
+	}
+	if _, ok := source.(*api.CSRCSource); !ok {
+		t.Errorf("NewSource() = %T, want *api.CSRCSource", source)
+	}
+}
+
+func TestSourceFlags_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		sf   SourceFlags
+	}{
+		{"unknown source", SourceFlags{Source: "ftp"}},
+		{"missing directory", SourceFlags{Source: "mirror", DataURL: filepath.Join(t.TempDir(), "missing")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.sf.NewSource(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestParseFlags_Help(t *testing.T) {
+	var stderr bytes.Buffer
+	code := Doctor(context.Background(), []string{"-h"}, &bytes.Buffer{}, &stderr)
+	if code != ExitOK {
+		t.Errorf("exit code = %d, want %d", code, ExitOK)
+	}
+	if !bytes.Contains(stderr.Bytes(), []byte("Usage: cmvp doctor")) {
+		t.Errorf("expected usage on stderr, got %q", stderr.String())
+	}
+
+	if code := Doctor(context.Background(), []string{"--bogus"}, &bytes.Buffer{}, &bytes.Buffer{}); code != ExitError {
+		t.Errorf("exit code for a bad flag = %d, want %d", code, ExitError)
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+)
+
+// Doctor fetches every dataset and reports upstream schema drift, so renamed
+// or retyped keys are noticed before they silently empty out fields.
+// It exits with ExitFailure if any dataset failed or drifted.
+func Doctor(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp doctor [flags]\n\nCheck the upstream datasets for schema drift.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+
+	source, err := sf.NewSource()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	reporter, ok := source.(api.SchemaReporter)
+	if !ok {
+		fmt.Fprintf(stderr, "Error: the %s source does not support schema validation\n", sf.Source)
+		return ExitError
+	}
+
+	var mu sync.Mutex
+	loaded := make(map[api.Dataset]api.Progress)
+	_, err = source.FetchAllModulesContext(ctx, func(p api.Progress) {
+		mu.Lock()
+		loaded[p.Dataset] = p
+		mu.Unlock()
+	})
+	var fetchErr *api.FetchError
+	if err != nil && !errors.As(err, &fetchErr) {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	warnings := reporter.SchemaWarnings()
+	byDataset := make(map[api.Dataset]int)
+	for _, w := range warnings {
+		byDataset[w.Dataset]++
+	}
+
+	if s, ok := source.(interface{ Source() string }); ok {
+		fmt.Fprintf(stdout, "Source: %s\n", s.Source())
+	}
+	if g, ok := source.(api.GeneratedAtSource); ok && g.GeneratedAt() != "" {
+		fmt.Fprintf(stdout, "Data as of: %s\n", g.GeneratedAt())
+	}
+	fmt.Fprintln(stdout)
+
+	tw := tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+	for _, ds := range api.Datasets {
+		p := loaded[ds]
+		switch {
+		case p.Err != nil:
+			fmt.Fprintf(tw, "%s\t\tFAILED: %v\n", ds, p.Err)
+		case byDataset[ds] > 0:
+			fmt.Fprintf(tw, "%s\t%d modules\t%d warning(s)\n", ds, p.Count, byDataset[ds])
+		default:
+			fmt.Fprintf(tw, "%s\t%d modules\tOK\n", ds, p.Count)
+		}
+	}
+	tw.Flush()
+
+	if len(warnings) > 0 {
+		fmt.Fprintln(stdout, "\nSchema warnings:")
+		tw = tabwriter.NewWriter(stdout, 0, 0, 2, ' ', 0)
+		for _, w := range warnings {
+			example := ""
+			if w.Example != "" {
+				example = "e.g. " + w.Example
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%q\t%s\t%d record(s)\t%s\n", w.Dataset, w.Issue, w.Key, w.Detail, w.Count, example)
+		}
+		tw.Flush()
+	}
+
+	if fetchErr != nil || len(warnings) > 0 {
+		return ExitFailure
+	}
+	fmt.Fprintln(stdout, "\nNo schema drift found.")
+	return ExitOK
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDoctor_Clean(t *testing.T) {
+	dir := writeDataDir(t, `{"modules": [{"Certificate Number": "1", "Vendor Name": "V", "Module Name": "M",
+		"Module Type": "Software", "Validation Date": "01/02/2024", "overall_level": 1}]}`)
+
+	var stdout, stderr bytes.Buffer
+	code := Doctor(context.Background(), []string{"--data-url", dir}, &stdout, &stderr)
+	if code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr: %s", code, ExitOK, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"Source: " + dir, "active modules", "1 modules", "No schema drift found."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDoctor_Drift(t *testing.T) {
+	dir := writeDataDir(t, `{"modules": [{"Certificate Number": "1", "Vendor": "V", "Module Name": "M",
+		"Module Type": "Software", "Validation Date": "01/02/2024", "overall_level": true}]}`)
+
+	var stdout bytes.Buffer
+	code := Doctor(context.Background(), []string{"--data-url", dir}, &stdout, &bytes.Buffer{})
+	if code != ExitFailure {
+		t.Fatalf("exit code = %d, want %d", code, ExitFailure)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"3 warning(s)",
+		`unknown key    "Vendor"`,
+		`missing key    "Vendor Name"`,
+		"expected number or string, got boolean",
+		"e.g. 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDoctor_UnsupportedSource(t *testing.T) {
+	var stderr bytes.Buffer
+	code := Doctor(context.Background(), []string{"--source", "csrc"}, &bytes.Buffer{}, &stderr)
+	if code != ExitError {
+		t.Errorf("exit code = %d, want %d", code, ExitError)
+	}
+	if !strings.Contains(stderr.String(), "does not support schema validation") {
+		t.Errorf("unexpected stderr: %q", stderr.String())
+	}
+}
+
+func TestDoctor_TypeDrift(t *testing.T) {
+	dir := writeDataDir(t, `{"modules": [{"Certificate Number": "1", "Vendor Name": "V", "Module Name": "M",
+		"Module Type": "Software", "Validation Date": "01/02/2024", "caveat": ["When operated in approved mode"]}]}`)
+
+	var stdout bytes.Buffer
+	code := Doctor(context.Background(), []string{"--data-url", dir}, &stdout, &bytes.Buffer{})
+	if code != ExitFailure {
+		t.Fatalf("exit code = %d, want %d", code, ExitFailure)
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		`FAILED: schema drift: type mismatch "caveat"`,
+		`type mismatch  "caveat"  expected string, got array of strings`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/index"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// PQC reports which validated modules implement post-quantum algorithms,
+// grouped by algorithm and most recently validated first. In-process
+// modules are not listed because NIST publishes no algorithm data for them.
+// It exits with ExitFailure if no module implements one.
+func PQC(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("pqc", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	historical := fs.Bool("historical", false, "Include historical modules")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp pqc [flags]\n\nReport post-quantum algorithm adoption (ML-KEM, ML-DSA, SLH-DSA, LMS, XMSS).\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+
+	modules, source, ok := sf.loadModules(ctx, stderr)
+	if !ok {
+		return ExitError
+	}
+	if s, ok := source.(api.SummarySource); ok && s.SummaryOnly() {
+		fmt.Fprintf(stderr, "Error: the %s source does not list module algorithms\n", sf.Source)
+		return ExitError
+	}
+	if !*historical {
+		current := modules[:0]
+		for _, m := range modules {
+			if m.Status != model.StatusHistorical {
+				current = append(current, m)
+			}
+		}
+		modules = current
+	}
+
+	report := index.NewAlgorithmIndex(modules).PQC()
+	if g, ok := source.(api.GeneratedAtSource); ok && g.GeneratedAt() != "" {
+		fmt.Fprintf(stdout, "Data as of: %s\n", g.GeneratedAt())
+	}
+	fmt.Fprintf(stdout, "%d module(s) from %d vendor(s) implement a post-quantum algorithm\n", len(report.Modules), report.Vendors())
+	fmt.Fprintf(stdout, "Algorithm data is not published for in-process modules.\n\n")
+	WritePQCReport(stdout, report)
+
+	if len(report.Modules) == 0 {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// WritePQCReport writes the standard coverage summary followed by the
+// modules implementing each algorithm
+func WritePQCReport(w io.Writer, report index.PQCReport) {
+	fmt.Fprintln(w, "Coverage:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, g := range report.Groups {
+		fmt.Fprintf(tw, "  %s\t%s\t%d active\t%d vendor(s)\n",
+			g.Standard, g.Name, g.Count(model.StatusActive), g.Vendors())
+	}
+	fmt.Fprintf(tw, "  FIPS 203+204+205\tall three\t%d module(s)\t\n", len(report.FullFIPS))
+	tw.Flush()
+
+	for _, g := range report.Groups {
+		if len(g.Modules) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s (%s)\n", g.Name, g.Standard)
+		tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "  CERT\tVENDOR\tMODULE\tSTATUS\tVALIDATED")
+		for _, m := range g.Modules {
+			cert, validated := m.CertificateNumber, "-"
+			if cert == "" {
+				cert = "-"
+			}
+			if !m.ValidationDate.IsZero() {
+				validated = m.ValidationDate.Format("2006-01-02")
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", cert, m.VendorName, m.ModuleName, m.Status, validated)
+		}
+		tw.Flush()
+	}
+}
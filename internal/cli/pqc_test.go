@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPQC(t *testing.T) {
+	dir := writeDataDir(t, `{"modules": [
+		{"Certificate Number": "1", "Vendor Name": "Old PQ", "Module Name": "First", "Validation Date": "01/02/2024", "algorithms": ["ML-KEM"]},
+		{"Certificate Number": "2", "Vendor Name": "New PQ", "Module Name": "Second", "Validation Date": "03/04/2025", "algorithms": ["ML-KEM", "ML-DSA", "SLH-DSA"]},
+		{"Certificate Number": "3", "Vendor Name": "Classic", "Module Name": "Third", "Validation Date": "05/06/2025", "algorithms": ["RSA"]}]}`)
+
+	var stdout, stderr bytes.Buffer
+	code := PQC(context.Background(), []string{"--data-url", dir}, &stdout, &stderr)
+	if code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr: %s", code, ExitOK, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"2 module(s) from 2 vendor(s)",
+		"FIPS 203",
+		"2 active",
+		"FIPS 203+204+205",
+		"ML-KEM (FIPS 203)",
+		"not published for in-process modules",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "0 in process") {
+		t.Error("coverage should not count in-process modules, which carry no algorithm data")
+	}
+	if strings.Contains(out, "Classic") {
+		t.Error("modules without PQC algorithms should not be listed")
+	}
+	if strings.Index(out, "New PQ") > strings.Index(out, "Old PQ") {
+		t.Error("expected the most recently validated module first")
+	}
+}
+
+func TestPQC_None(t *testing.T) {
+	dir := writeDataDir(t, `{"modules": [{"Certificate Number": "1", "Vendor Name": "V", "Module Name": "M", "algorithms": ["AES"]}]}`)
+
+	code := PQC(context.Background(), []string{"--data-url", dir}, &bytes.Buffer{}, &bytes.Buffer{})
+	if code != ExitFailure {
+		t.Errorf("exit code = %d, want %d", code, ExitFailure)
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/report"
+)
+
+// Report renders a Markdown or HTML document describing the certificates
+// named on the command line or in a file. It exits with ExitNotFound if
+// any certificate does not exist, without writing a partial report.
+func Report(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	format := fs.String("format", "markdown", "Output format: markdown or html")
+	templatePath := fs.String("template", "", "Go text/template `file` to render instead of the built-in template")
+	certFile := fs.String("file", "", "Read certificate numbers from `file`, separated by whitespace or commas")
+	title := fs.String("title", "FIPS 140 Validated Cryptographic Modules", "Report title")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp report [flags] [certificate...]\n\nRender a certificate report for an SSP appendix or ATO package.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+
+	f, err := report.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	var tmpl *report.Report
+	if *templatePath != "" {
+		tmpl, err = report.Load(f, *templatePath)
+	} else {
+		tmpl, err = report.New(f)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	certs := fs.Args()
+	if *certFile != "" {
+		fromFile, err := readCertFile(*certFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return ExitError
+		}
+		certs = append(certs, fromFile...)
+	}
+	certs = uniqueCerts(certs)
+	if len(certs) == 0 {
+		fs.Usage()
+		return ExitError
+	}
+
+	all, source, ok := sf.loadModules(ctx, stderr)
+	if !ok {
+		return ExitError
+	}
+	byCert := make(map[string]model.Module, len(all))
+	for _, m := range all {
+		if m.CertificateNumber != "" {
+			byCert[m.CertificateNumber] = m
+		}
+	}
+	// Summary-only sources list every module but need a request per
+	// certificate for the caveat, level and algorithms
+	s, isSummary := source.(api.SummarySource)
+	summaryOnly := isSummary && s.SummaryOnly()
+
+	modules := make([]model.Module, 0, len(certs))
+	missing := 0
+	for _, cert := range certs {
+		mod, ok := byCert[cert]
+		if !ok {
+			fmt.Fprintf(stderr, "Certificate %s not found\n", cert)
+			missing++
+			continue
+		}
+		if summaryOnly {
+			full, err := source.FetchModuleContext(ctx, cert)
+			if err != nil {
+				fmt.Fprintf(stderr, "Error: %v\n", err)
+				return ExitError
+			}
+			mod = *full
+		}
+		modules = append(modules, mod)
+	}
+	if missing > 0 {
+		return ExitNotFound
+	}
+
+	data := report.Data{Title: *title, Generated: time.Now(), Modules: modules}
+	if g, ok := source.(api.GeneratedAtSource); ok {
+		data.DataAsOf = g.GeneratedAt()
+	}
+	if err := tmpl.Render(stdout, data); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+func readCertFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return report.ReadCerts(f)
+}
+
+// uniqueCerts drops repeated certificates and any leading "#", keeping the
+// first occurrence of each
+func uniqueCerts(certs []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, cert := range certs {
+		cert = strings.TrimPrefix(strings.TrimSpace(cert), "#")
+		if cert == "" || seen[cert] {
+			continue
+		}
+		seen[cert] = true
+		unique = append(unique, cert)
+	}
+	return unique
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const reportData = `{"modules": [
+	{"Certificate Number": "4282", "Vendor Name": "OpenSSL", "Module Name": "Provider", "Validation Date": "01/02/2024",
+	 "overall_level": 1, "caveat": "When operated in approved mode", "algorithms_detailed": ["AES-GCM: (A1234) 128, 256"]},
+	{"Certificate Number": "4718", "Vendor Name": "Acme", "Module Name": "HSM", "Validation Date": "03/04/2024", "overall_level": 3}]}`
+
+func TestReport(t *testing.T) {
+	dir := writeDataDir(t, reportData)
+	certFile := filepath.Join(t.TempDir(), "certs.txt")
+	if err := os.WriteFile(certFile, []byte("# Boundary modules\n4718\n#4282\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--data-url", dir, "--file", certFile, "--title", "Appendix Q", "4282"}
+	if code := Report(context.Background(), args, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr: %s", code, ExitOK, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"# Appendix Q", "## Provider (Certificate #4282)", "When operated in approved mode", "| AES | GCM | 128, 256 | A1234 |", "## HSM (Certificate #4718)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	// Certificates are reported once, in the order given
+	if strings.Count(out, "## Provider") != 1 || strings.Index(out, "## Provider") > strings.Index(out, "## HSM") {
+		t.Errorf("expected 4282 once, before 4718:\n%s", out)
+	}
+}
+
+func TestReport_Template(t *testing.T) {
+	dir := writeDataDir(t, reportData)
+	tmpl := filepath.Join(t.TempDir(), "ssp.tmpl")
+	if err := os.WriteFile(tmpl, []byte(`{{range .Modules}}<p>{{.CertificateNumber}} {{.VendorName}} {{.OverallLevel}}</p>{{end}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--data-url", dir, "--format", "html", "--template", tmpl, "4718"}
+	if code := Report(context.Background(), args, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("exit code = %d; stderr: %s", code, stderr.String())
+	}
+	if got := stdout.String(); got != "<p>4718 Acme Level 3</p>" {
+		t.Errorf("output = %q", got)
+	}
+}
+
+func TestReport_Errors(t *testing.T) {
+	dir := writeDataDir(t, reportData)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"not found", []string{"4282", "9999"}, ExitNotFound},
+		{"no certificates", nil, ExitError},
+		{"bad format", []string{"--format", "pdf", "4282"}, ExitError},
+		{"missing template", []string{"--template", filepath.Join(dir, "nope.tmpl"), "4282"}, ExitError},
+		{"missing file", []string{"--file", filepath.Join(dir, "nope.txt")}, ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"--data-url", dir}, tt.args...)
+			if code := Report(context.Background(), args, &stdout, &stderr); code != tt.code {
+				t.Errorf("exit code = %d, want %d; stderr: %s", code, tt.code, stderr.String())
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("expected no report, got %q", stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/export"
+	"github.com/ethanolivertroy/cmvp-tui/internal/query"
+)
+
+// Search prints the modules matching a filter query, narrowed by the
+// --status and --level flags. It exits with ExitFailure if nothing matched.
+func Search(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	format := fs.String("format", "table", "Output format: table, json, ndjson, csv or markdown")
+	status := fs.String("status", "", "Comma-separated statuses to include: active, historical, in-process")
+	level := fs.String("level", "", "Overall level, e.g. 2, >=2 or tested")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp search [flags] [query]\n\nPrint the modules matching a filter query, e.g.\n\n  cmvp search --status active --format csv 'vendor:openssl algo:ML-KEM'\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+
+	out, err := export.ParseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	queries, err := searchQueries(strings.Join(fs.Args(), " "), *status, *level)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	modules, _, ok := sf.loadModules(ctx, stderr)
+	if !ok {
+		return ExitError
+	}
+	for _, q := range queries {
+		modules = q.Filter(modules)
+	}
+
+	if err := export.Write(stdout, out, modules); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	if len(modules) == 0 {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// searchQueries parses the query text and the --status and --level flags,
+// which are written in the same query language
+func searchQueries(text, status, level string) ([]*query.Query, error) {
+	q, err := query.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	queries := []*query.Query{q}
+
+	if status != "" {
+		var terms []string
+		for _, s := range strings.Split(status, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				terms = append(terms, "status:"+s)
+			}
+		}
+		q, err := query.Parse(strings.Join(terms, " OR "))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --status: %w", err)
+		}
+		queries = append(queries, q)
+	}
+
+	if level = strings.TrimSpace(level); level != "" {
+		term := "level:" + level
+		if strings.ContainsAny(level[:1], "<>=!") {
+			term = "level" + level
+		}
+		q, err := query.Parse(term)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --level: %w", err)
+		}
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
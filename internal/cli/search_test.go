@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const searchData = `{"modules": [
+	{"Certificate Number": "1", "Vendor Name": "OpenSSL", "Module Name": "Provider", "Validation Date": "01/02/2024", "overall_level": 1},
+	{"Certificate Number": "2", "Vendor Name": "Acme", "Module Name": "HSM", "Validation Date": "03/04/2024", "overall_level": 3}]}`
+
+func TestSearch(t *testing.T) {
+	dir := writeDataDir(t, searchData)
+
+	tests := []struct {
+		name  string
+		args  []string
+		code  int
+		certs []string
+	}{
+		{"query", []string{"vendor:openssl"}, ExitOK, []string{"1"}},
+		{"level flag", []string{"--level", ">=2"}, ExitOK, []string{"2"}},
+		{"status flag", []string{"--status", "in-process"}, ExitOK, []string{""}},
+		{"statuses", []string{"--status", "active,in-process", "--level", "1"}, ExitOK, []string{"1"}},
+		{"no match", []string{"vendor:nobody"}, ExitFailure, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"--data-url", dir, "--format", "ndjson"}, tt.args...)
+			if code := Search(context.Background(), args, &stdout, &stderr); code != tt.code {
+				t.Fatalf("exit code = %d, want %d; stderr: %s", code, tt.code, stderr.String())
+			}
+			var certs []string
+			dec := json.NewDecoder(&stdout)
+			for dec.More() {
+				var r struct {
+					CertificateNumber string `json:"certificate_number"`
+				}
+				if err := dec.Decode(&r); err != nil {
+					t.Fatal(err)
+				}
+				certs = append(certs, r.CertificateNumber)
+			}
+			if strings.Join(certs, ",") != strings.Join(tt.certs, ",") {
+				t.Errorf("certs = %v, want %v", certs, tt.certs)
+			}
+		})
+	}
+}
+
+func TestSearch_Errors(t *testing.T) {
+	dir := writeDataDir(t, searchData)
+
+	for _, args := range [][]string{
+		{"--format", "xml"},
+		{"vendor:(openssl"},
+		{"--status", "retired"},
+		{"--level", ">=x"},
+	} {
+		var stderr bytes.Buffer
+		code := Search(context.Background(), append([]string{"--data-url", dir}, args...), &bytes.Buffer{}, &stderr)
+		if code != ExitError || !strings.HasPrefix(stderr.String(), "Error: ") {
+			t.Errorf("%v: exit code = %d, stderr = %q, want a usage error", args, code, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/export"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/ui"
+)
+
+// Styles for the text output of show, matching the TUI's detail view.
+// Colors are dropped when stdout is not a terminal or NO_COLOR is set.
+var (
+	showTitleStyle  = ui.DetailTitleStyle.UnsetMarginBottom()
+	showLabelStyle  = ui.DetailLabelStyle
+	showURLStyle    = ui.DetailURLStyle
+	showCaveatStyle = ui.CaveatStyle
+	showHeaderStyle = ui.HeaderStyle
+)
+
+// Show prints the full record for a certificate number as text, JSON or
+// YAML. It exits with ExitNotFound if there is no such certificate.
+func Show(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("show", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	format := fs.String("format", "text", "Output format: text, json or yaml")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp show [flags] <certificate>\n\nPrint the full record for a certificate number.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+	// Accept flags after the certificate number too
+	cert := fs.Arg(0)
+	if fs.NArg() > 1 {
+		if code, ok := parseFlags(fs, fs.Args()[1:], stderr); !ok {
+			return code
+		}
+		if fs.NArg() > 0 {
+			fmt.Fprintf(stderr, "Error: unexpected arguments %q\n", fs.Args())
+			return ExitError
+		}
+	}
+	if cert == "" {
+		fs.Usage()
+		return ExitError
+	}
+	if *format != "text" && *format != "json" && *format != "yaml" {
+		fmt.Fprintf(stderr, "Error: unknown format %q (want text, json or yaml)\n", *format)
+		return ExitError
+	}
+
+	source, err := sf.NewSource()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	mod, err := source.FetchModuleContext(ctx, strings.TrimPrefix(cert, "#"))
+	if errors.Is(err, api.ErrModuleNotFound) {
+		fmt.Fprintf(stderr, "Certificate %s not found\n", cert)
+		return ExitNotFound
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(export.NewRecord(*mod))
+	case "yaml":
+		err = export.WriteYAML(stdout, export.NewRecord(*mod))
+	default:
+		writeModuleText(stdout, *mod, time.Now())
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	return ExitOK
+}
+
+// writeModuleText writes everything the TUI detail view shows for mod
+func writeModuleText(w io.Writer, mod model.Module, now time.Time) {
+	title := mod.ModuleName + "  [" + mod.Status.String()
+	if !mod.OverallLevel.IsZero() {
+		title += ", " + mod.OverallLevel.String()
+	}
+	fmt.Fprintln(w, showTitleStyle.Render(title+"]"))
+	fmt.Fprintln(w)
+
+	if mod.Caveat != "" {
+		fmt.Fprintln(w, showHeaderStyle.Render("Caveat"))
+		fmt.Fprintln(w, showCaveatStyle.Render(mod.Caveat))
+		fmt.Fprintln(w)
+	}
+
+	var countdown string
+	if days, ok := mod.DaysUntilSunset(now); ok && mod.Status == model.StatusActive {
+		if days >= 0 {
+			countdown = fmt.Sprintf("(in %d days)", days)
+		} else {
+			countdown = fmt.Sprintf("(%d days ago)", -days)
+		}
+	}
+	for _, f := range ui.DetailFields(mod, countdown) {
+		if f.Value == "" {
+			continue
+		}
+		value := f.Value
+		if f.IsURL {
+			value = showURLStyle.Render(value)
+		}
+		fmt.Fprintln(w, showLabelStyle.Render(f.Label)+value)
+	}
+
+	if len(mod.OverallLevel.Areas) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, showHeaderStyle.Render("Levels by Area"))
+		for _, a := range mod.OverallLevel.Areas {
+			fmt.Fprintf(w, "  Level %d  %s\n", a.Level, a.Area)
+		}
+	}
+
+	if mod.Description != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, showHeaderStyle.Render("Description"))
+		fmt.Fprintln(w, lipgloss.NewStyle().Width(80).Render(mod.Description))
+	}
+
+	if entries := mod.AlgorithmEntries(); len(entries) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, showHeaderStyle.Render("Algorithms"))
+		writeAlgorithmTable(w, entries)
+	} else if len(mod.Algorithms) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, showLabelStyle.Render("Algorithms:")+strings.Join(mod.Algorithms, ", "))
+	}
+}
+
+// writeAlgorithmTable writes parsed algorithm entries as aligned columns
+func writeAlgorithmTable(w io.Writer, entries []model.AlgorithmEntry) {
+	rows := [][]string{{"ALGORITHM", "MODE", "KEY SIZES", "CAVP", "STATUS"}}
+	for _, e := range entries {
+		sizes := make([]string, len(e.KeySizes))
+		for i, n := range e.KeySizes {
+			sizes[i] = strconv.Itoa(n)
+		}
+		status := "Approved"
+		if !e.Approved {
+			status = "Non-approved"
+		}
+		rows = append(rows, []string{e.Family, e.Mode, strings.Join(sizes, ", "), e.CAVPCert, status})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+	for r, row := range rows {
+		var line strings.Builder
+		line.WriteString("  ")
+		for i, cell := range row {
+			if i < len(row)-1 {
+				cell += strings.Repeat(" ", widths[i]-lipgloss.Width(cell)+2)
+			}
+			line.WriteString(cell)
+		}
+		if r == 0 {
+			fmt.Fprintln(w, showHeaderStyle.Render(line.String()))
+		} else {
+			fmt.Fprintln(w, line.String())
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const showData = `{"modules": [
+	{"Certificate Number": "4282", "Vendor Name": "OpenSSL", "Module Name": "Provider", "Module Type": "Software",
+	 "Validation Date": "01/02/2024", "sunset_date": "01/01/2029", "overall_level": 1, "standard": "FIPS 140-3",
+	 "caveat": "When operated in approved mode", "embodiment": "Multi-Chip Stand Alone", "lab": "ACME LABS",
+	 "algorithms_detailed": ["AES-GCM: (A1234) 128, 256"], "security_policy_url": "https://example.com/sp.pdf"}]}`
+
+func TestShow(t *testing.T) {
+	dir := writeDataDir(t, showData)
+
+	var stdout, stderr bytes.Buffer
+	if code := Show(context.Background(), []string{"--data-url", dir, "4282"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("exit code = %d, want %d; stderr: %s", code, ExitOK, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"Provider", "When operated in approved mode", "Multi-Chip Stand Alone",
+		"ACME LABS", "January 1, 2029", "AES", "GCM", "A1234", "https://example.com/sp.pdf"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestShow_Formats(t *testing.T) {
+	dir := writeDataDir(t, showData)
+
+	var stdout, stderr bytes.Buffer
+	if code := Show(context.Background(), []string{"--data-url", dir, "4282", "--format", "json"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("json: exit code = %d; stderr: %s", code, stderr.String())
+	}
+	var r struct {
+		CertificateNumber string `json:"certificate_number"`
+		Caveat            string `json:"caveat"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &r); err != nil {
+		t.Fatal(err)
+	}
+	if r.CertificateNumber != "4282" || r.Caveat == "" {
+		t.Errorf("json record = %+v", r)
+	}
+
+	stdout.Reset()
+	if code := Show(context.Background(), []string{"--data-url", dir, "--format", "yaml", "4282"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("yaml: exit code = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `certificate_number: "4282"`) {
+		t.Errorf("yaml output missing certificate number:\n%s", stdout.String())
+	}
+}
+
+func TestShow_Errors(t *testing.T) {
+	dir := writeDataDir(t, showData)
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"not found", []string{"9999"}, ExitNotFound},
+		{"no certificate", nil, ExitError},
+		{"two certificates", []string{"4282", "4283"}, ExitError},
+		{"bad format", []string{"--format", "xml", "4282"}, ExitError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"--data-url", dir}, tt.args...)
+			if code := Show(context.Background(), args, &stdout, &stderr); code != tt.code {
+				t.Errorf("exit code = %d, want %d; stderr: %s", code, tt.code, stderr.String())
+			}
+		})
+	}
+}
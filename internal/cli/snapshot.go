@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/snapshot"
+)
+
+// Snapshot saves the combined dataset to a file stamped with its
+// generated_at, for later comparison with Diff
+func Snapshot(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	output := fs.String("o", "", "Write the snapshot to `file` (default cmvp-snapshot-<generated_at>.json)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp snapshot [flags]\n\nSave the combined dataset for comparison with cmvp diff.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+
+	s, ok := currentSnapshot(ctx, &sf, stderr)
+	if !ok {
+		return ExitError
+	}
+	path := *output
+	if path == "" {
+		path = s.FileName()
+	}
+	if err := s.Save(path); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	fmt.Fprintf(stdout, "Saved %d modules as of %s to %s\n", len(s.Modules), s.Label(), path)
+	return ExitOK
+}
+
+// Diff compares two snapshots, or a snapshot and the current data. Like
+// diff(1) it exits with ExitFailure if anything changed.
+func Diff(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	var sf SourceFlags
+	sf.Register(fs)
+	format := fs.String("format", "text", "Output format: text or json")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: cmvp diff [flags] <old-snapshot> [<new-snapshot>]\n\nReport certificates added, removed or changed between two snapshots.\nWithout a new snapshot, compare against the current data.\n\nFlags:")
+		fs.PrintDefaults()
+	}
+	if code, ok := parseFlags(fs, args, stderr); !ok {
+		return code
+	}
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		fs.Usage()
+		return ExitError
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(stderr, "Error: unknown format %q (want text or json)\n", *format)
+		return ExitError
+	}
+
+	older, err := snapshot.Load(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return ExitError
+	}
+	var newer *snapshot.Snapshot
+	if fs.NArg() == 2 {
+		if newer, err = snapshot.Load(fs.Arg(1)); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return ExitError
+		}
+	} else {
+		var ok bool
+		if newer, ok = currentSnapshot(ctx, &sf, stderr); !ok {
+			return ExitError
+		}
+	}
+
+	d := snapshot.Compare(older, newer)
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(d); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return ExitError
+		}
+	} else {
+		WriteDiff(stdout, d)
+	}
+
+	if d.Empty() {
+		return ExitOK
+	}
+	return ExitFailure
+}
+
+// currentSnapshot loads every dataset and captures it. A partial load is
+// an error, as the missing modules would be reported as removed.
+func currentSnapshot(ctx context.Context, sf *SourceFlags, stderr io.Writer) (*snapshot.Snapshot, bool) {
+	source, err := sf.NewSource()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return nil, false
+	}
+	modules, err := source.FetchAllModulesContext(ctx, nil)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return nil, false
+	}
+	if s, ok := source.(api.SummarySource); ok && s.SummaryOnly() {
+		fmt.Fprintf(stderr, "Warning: the %s source does not list caveats or algorithms, so changes to them are not recorded\n", sf.Source)
+	}
+	var generatedAt string
+	if g, ok := source.(api.GeneratedAtSource); ok {
+		generatedAt = g.GeneratedAt()
+	}
+	return snapshot.New(modules, generatedAt, time.Now()), true
+}
+
+// WriteDiff writes the added, removed and changed certificates in d
+func WriteDiff(w io.Writer, d snapshot.Diff) {
+	fmt.Fprintf(w, "Changes from %s to %s: %d added, %d removed, %d changed\n",
+		d.From, d.To, len(d.Added), len(d.Removed), len(d.Changed))
+	if d.Empty() {
+		return
+	}
+
+	writeRecords := func(title string, records []snapshot.Record) {
+		if len(records) == 0 {
+			return
+		}
+		fmt.Fprintf(w, "\n%s:\n", title)
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, r := range records {
+			fmt.Fprintf(tw, "  #%s\t%s\t%s\t%s\n", r.CertificateNumber, r.VendorName, r.ModuleName, r.Status)
+		}
+		tw.Flush()
+	}
+	writeRecords("Added", d.Added)
+	writeRecords("Removed", d.Removed)
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintln(w, "\nChanged:")
+		for _, c := range d.Changed {
+			fmt.Fprintf(w, "  #%s  %s  %s\n", c.Module.CertificateNumber, c.Module.VendorName, c.Module.ModuleName)
+			for _, line := range c.Descriptions() {
+				fmt.Fprintf(w, "      %s\n", line)
+			}
+		}
+	}
+}
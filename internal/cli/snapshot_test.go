@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	snapshotOld = `{"metadata": {"generated_at": "2026-01-01T00:00:00Z"}, "modules": [
+	{"Certificate Number": "4282", "Vendor Name": "OpenSSL", "Module Name": "Provider", "Validation Date": "01/02/2024", "sunset_date": "01/01/2029", "algorithms": ["AES"]},
+	{"Certificate Number": "3000", "Vendor Name": "Acme", "Module Name": "Old HSM", "Validation Date": "03/04/2020"}]}`
+	snapshotNew = `{"metadata": {"generated_at": "2026-02-01T00:00:00Z"}, "modules": [
+	{"Certificate Number": "4282", "Vendor Name": "OpenSSL", "Module Name": "Provider", "Validation Date": "01/02/2024", "sunset_date": "01/01/2030",
+	 "caveat": "When operated in approved mode", "algorithms": ["AES", "ML-KEM"]},
+	{"Certificate Number": "5000", "Vendor Name": "Acme", "Module Name": "New HSM", "Validation Date": "01/05/2026"}]}`
+)
+
+// saveSnapshot runs cmvp snapshot against active and returns the file
+func saveSnapshot(t *testing.T, active string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "snap.json")
+	var stdout, stderr bytes.Buffer
+	args := []string{"--data-url", writeDataDir(t, active), "-o", path}
+	if code := Snapshot(context.Background(), args, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("snapshot exit code = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "as of 2026-") {
+		t.Errorf("snapshot output = %q, want the generated_at stamp", stdout.String())
+	}
+	return path
+}
+
+func TestSnapshot_DefaultName(t *testing.T) {
+	dir := writeDataDir(t, snapshotOld)
+	t.Chdir(t.TempDir())
+
+	var stdout, stderr bytes.Buffer
+	if code := Snapshot(context.Background(), []string{"--data-url", dir}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("exit code = %d; stderr: %s", code, stderr.String())
+	}
+	if _, err := os.Stat("cmvp-snapshot-20260101T000000Z.json"); err != nil {
+		t.Errorf("expected a snapshot named for its generated_at: %v", err)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	older := saveSnapshot(t, snapshotOld)
+	newer := saveSnapshot(t, snapshotNew)
+
+	var stdout, stderr bytes.Buffer
+	if code := Diff(context.Background(), []string{older, newer}, &stdout, &stderr); code != ExitFailure {
+		t.Fatalf("exit code = %d, want %d; stderr: %s", code, ExitFailure, stderr.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{
+		"Changes from 2026-01-01T00:00:00Z to 2026-02-01T00:00:00Z: 1 added, 1 removed, 1 changed",
+		"Added:\n  #5000",
+		"Removed:\n  #3000",
+		"#4282  OpenSSL  Provider",
+		"Caveat added: When operated in approved mode",
+		"Sunset 2029-01-01 → 2030-01-01",
+		"Algorithms added: ML-KEM",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("diff missing %q:\n%s", want, out)
+		}
+	}
+
+	stdout.Reset()
+	if code := Diff(context.Background(), []string{"--format", "json", newer, newer}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("identical snapshots: exit code = %d, want %d", code, ExitOK)
+	}
+	var d struct {
+		From  string            `json:"from"`
+		Added []json.RawMessage `json:"added"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &d); err != nil || d.From != "2026-02-01T00:00:00Z" || len(d.Added) != 0 {
+		t.Errorf("json diff = %s (%v)", stdout.String(), err)
+	}
+}
+
+func TestDiff_CurrentData(t *testing.T) {
+	older := saveSnapshot(t, snapshotOld)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"--data-url", writeDataDir(t, snapshotNew), older}
+	if code := Diff(context.Background(), args, &stdout, &stderr); code != ExitFailure {
+		t.Fatalf("exit code = %d; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "to 2026-02-01T00:00:00Z") {
+		t.Errorf("expected a comparison with the current data:\n%s", stdout.String())
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	snap := saveSnapshot(t, snapshotOld)
+	for _, args := range [][]string{
+		nil,
+		{snap, snap, snap},
+		{"--format", "xml", snap, snap},
+		{snap, filepath.Join(t.TempDir(), "missing.json")},
+	} {
+		var stdout, stderr bytes.Buffer
+		if code := Diff(context.Background(), args, &stdout, &stderr); code != ExitError {
+			t.Errorf("%q: exit code = %d, want %d", args, code, ExitError)
+		}
+	}
+}
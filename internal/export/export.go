@@ -0,0 +1,249 @@
+// Package export writes module lists as tables, JSON, NDJSON, CSV and
+// Markdown for the cmvp subcommands and the TUI
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// Format is an output format for a list of modules
+type Format string
+
+const (
+	FormatTable    Format = "table"
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatTable, FormatJSON, FormatNDJSON, FormatCSV, FormatMarkdown}
+
+// ParseFormat returns the format called name, ignoring case
+func ParseFormat(name string) (Format, error) {
+	for _, f := range Formats {
+		if strings.EqualFold(name, string(f)) {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unknown format %q (want %s)", name, strings.Join(names, ", "))
+}
+
+// FormatForPath picks the format for a file from its extension: .csv,
+// .json, .ndjson/.jsonl or .md/.markdown
+func FormatForPath(path string) (Format, error) {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return FormatCSV, nil
+	case ".json":
+		return FormatJSON, nil
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON, nil
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	case "":
+		return "", fmt.Errorf("%s has no extension (want .csv, .json or .md)", filepath.Base(path))
+	default:
+		return "", fmt.Errorf("cannot export to %s files (want .csv, .json or .md)", ext)
+	}
+}
+
+// Record is the exported form of a module
+type Record struct {
+	CertificateNumber  string                 `json:"certificate_number,omitempty"`
+	ModuleName         string                 `json:"module_name"`
+	VendorName         string                 `json:"vendor_name"`
+	Status             string                 `json:"status"`
+	Stage              string                 `json:"stage,omitempty"`
+	ModuleType         string                 `json:"module_type,omitempty"`
+	Standard           string                 `json:"standard,omitempty"`
+	Embodiment         string                 `json:"embodiment,omitempty"`
+	Lab                string                 `json:"lab,omitempty"`
+	OverallLevel       *Level                 `json:"overall_level,omitempty"`
+	ValidationDate     string                 `json:"validation_date,omitempty"`
+	SunsetDate         string                 `json:"sunset_date,omitempty"`
+	Caveat             string                 `json:"caveat,omitempty"`
+	Description        string                 `json:"description,omitempty"`
+	Algorithms         []string               `json:"algorithms,omitempty"`
+	AlgorithmsDetailed []model.AlgorithmEntry `json:"algorithms_detailed,omitempty"`
+	CertificateURL     string                 `json:"certificate_url,omitempty"`
+	SecurityPolicyURL  string                 `json:"security_policy_url,omitempty"`
+}
+
+// Level is the exported form of a model.SecurityLevel
+type Level struct {
+	Level int         `json:"level,omitempty"` // 1-4, omitted if none was published
+	Text  string      `json:"text"`            // As displayed, e.g. "Level 2" or "Levels 1-3"
+	Areas []AreaLevel `json:"areas,omitempty"`
+}
+
+// AreaLevel is the level achieved in one security area
+type AreaLevel struct {
+	Area  string `json:"area"`
+	Level int    `json:"level"`
+}
+
+// NewRecord converts mod to its exported form
+func NewRecord(mod model.Module) Record {
+	r := Record{
+		CertificateNumber:  mod.CertificateNumber,
+		ModuleName:         mod.ModuleName,
+		VendorName:         mod.VendorName,
+		Status:             mod.Status.String(),
+		Stage:              string(mod.Stage),
+		ModuleType:         mod.ModuleType,
+		Standard:           mod.Standard,
+		Embodiment:         mod.Embodiment,
+		Lab:                mod.Lab,
+		ValidationDate:     model.FormatDate(mod.ValidationDate),
+		SunsetDate:         model.FormatDate(mod.SunsetDate),
+		Caveat:             mod.Caveat,
+		Description:        mod.Description,
+		Algorithms:         mod.Algorithms,
+		AlgorithmsDetailed: mod.AlgorithmEntries(),
+		CertificateURL:     mod.CertificateURL,
+		SecurityPolicyURL:  mod.SecurityPolicyURL,
+	}
+	if !mod.OverallLevel.IsZero() {
+		r.OverallLevel = &Level{Level: mod.OverallLevel.Level, Text: mod.OverallLevel.String()}
+		for _, a := range mod.OverallLevel.Areas {
+			r.OverallLevel.Areas = append(r.OverallLevel.Areas, AreaLevel{Area: a.Area, Level: a.Level})
+		}
+	}
+	if len(r.AlgorithmsDetailed) == 0 {
+		r.AlgorithmsDetailed = nil
+	}
+	return r
+}
+
+// Records converts modules to their exported form
+func Records(modules []model.Module) []Record {
+	records := make([]Record, len(modules))
+	for i, m := range modules {
+		records[i] = NewRecord(m)
+	}
+	return records
+}
+
+// Write writes modules to w in format
+func Write(w io.Writer, format Format, modules []model.Module) error {
+	switch format {
+	case FormatTable:
+		return WriteTable(w, modules)
+	case FormatJSON:
+		return WriteJSON(w, modules)
+	case FormatNDJSON:
+		return WriteNDJSON(w, modules)
+	case FormatCSV:
+		return WriteCSV(w, modules)
+	case FormatMarkdown:
+		return WriteMarkdown(w, modules)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+// WriteTable writes an aligned table of the modules' main fields
+func WriteTable(w io.Writer, modules []model.Module) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CERT\tVENDOR\tMODULE\tSTATUS\tLEVEL\tVALIDATED\tSUNSET")
+	for _, m := range modules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			orDash(m.CertificateNumber),
+			orDash(truncate(m.VendorName, 40)),
+			orDash(truncate(m.ModuleName, 50)),
+			m.Status,
+			orDash(truncate(m.OverallLevel.String(), 20)),
+			orDash(model.FormatDate(m.ValidationDate)),
+			orDash(model.FormatDate(m.SunsetDate)),
+		)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the modules as an indented JSON array
+func WriteJSON(w io.Writer, modules []model.Module) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Records(modules))
+}
+
+// WriteNDJSON writes one JSON object per line
+func WriteNDJSON(w io.Writer, modules []model.Module) error {
+	enc := json.NewEncoder(w)
+	for _, m := range modules {
+		if err := enc.Encode(NewRecord(m)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvHeader names the CSV columns. List fields are joined with "; ".
+var csvHeader = []string{
+	"certificate_number", "module_name", "vendor_name", "status", "stage", "module_type", "standard",
+	"embodiment", "lab", "overall_level", "validation_date", "sunset_date", "algorithms", "cavp_certs",
+	"caveat", "certificate_url",
+}
+
+// WriteCSV writes the modules as CSV with a header row
+func WriteCSV(w io.Writer, modules []model.Module) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, m := range modules {
+		err := cw.Write([]string{
+			m.CertificateNumber,
+			m.ModuleName,
+			m.VendorName,
+			m.Status.String(),
+			string(m.Stage),
+			m.ModuleType,
+			m.Standard,
+			m.Embodiment,
+			m.Lab,
+			m.OverallLevel.String(),
+			model.FormatDate(m.ValidationDate),
+			model.FormatDate(m.SunsetDate),
+			strings.Join(m.AlgorithmNames(), "; "),
+			strings.Join(m.CAVPCerts(), "; "),
+			m.Caveat,
+			m.CertificateURL,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// truncate shortens s to n runes, ending with an ellipsis
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
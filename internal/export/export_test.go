@@ -0,0 +1,140 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+var testModules = []model.Module{
+	{
+		CertificateNumber:  "4282",
+		ModuleName:         "OpenSSL FIPS Provider",
+		VendorName:         "OpenSSL Software Foundation",
+		Status:             model.StatusActive,
+		OverallLevel:       model.NewSecurityLevel(1),
+		ValidationDate:     time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC),
+		SunsetDate:         time.Date(2029, 7, 11, 0, 0, 0, 0, time.UTC),
+		Algorithms:         []string{"AES", "SHS"},
+		AlgorithmsDetailed: []string{"AES-GCM 128, 256 (A4510)", "SHA-256 (A4510)"},
+		Caveat:             "When operated in approved mode, \"quoted\"",
+	},
+	{
+		ModuleName:   "Pending Module",
+		VendorName:   "Acme",
+		Status:       model.StatusInProcess,
+		Stage:        model.StageInReview,
+		OverallLevel: model.ParseSecurityLevel("Tested Configuration(s)"),
+	},
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("NDJSON"); err != nil || f != FormatNDJSON {
+		t.Errorf("ParseFormat(NDJSON) = %q, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil || !strings.Contains(err.Error(), "table, json, ndjson, csv, markdown") {
+		t.Errorf("ParseFormat(xml) error = %v, want the supported formats listed", err)
+	}
+}
+
+func TestNewRecord(t *testing.T) {
+	r := NewRecord(testModules[0])
+	if r.OverallLevel == nil || r.OverallLevel.Level != 1 || r.OverallLevel.Text != "Level 1" {
+		t.Errorf("OverallLevel = %+v", r.OverallLevel)
+	}
+	if r.ValidationDate != "2024-07-12" || r.SunsetDate != "2029-07-11" {
+		t.Errorf("dates = %q, %q", r.ValidationDate, r.SunsetDate)
+	}
+	if len(r.AlgorithmsDetailed) != 2 || r.AlgorithmsDetailed[0].Mode != "GCM" || r.AlgorithmsDetailed[0].CAVPCert != "A4510" {
+		t.Errorf("AlgorithmsDetailed = %+v", r.AlgorithmsDetailed)
+	}
+
+	pending := NewRecord(testModules[1])
+	if pending.OverallLevel.Level != 0 || pending.OverallLevel.Text != "Tested Configuration(s)" {
+		t.Errorf("OverallLevel = %+v, want the text level", pending.OverallLevel)
+	}
+	if NewRecord(model.Module{}).OverallLevel != nil {
+		t.Error("expected no level when none was published")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, testModules); err != nil {
+		t.Fatal(err)
+	}
+	var records []Record
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(records) != 2 || records[1].Stage != "In Review" {
+		t.Errorf("records = %+v", records)
+	}
+
+	buf.Reset()
+	if err := Write(&buf, FormatJSON, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("empty JSON = %q, %v, want []", buf.String(), err)
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatNDJSON, testModules); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var r Record
+	if err := json.Unmarshal([]byte(lines[0]), &r); err != nil || r.CertificateNumber != "4282" {
+		t.Errorf("first line = %s, %v", lines[0], err)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatCSV, testModules); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(rows) != 3 || rows[0][0] != "certificate_number" {
+		t.Fatalf("rows = %v", rows)
+	}
+	row := make(map[string]string)
+	for i, col := range rows[0] {
+		row[col] = rows[1][i]
+	}
+	if row["algorithms"] != "AES; SHA2-256; SHS" || row["cavp_certs"] != "A4510" || row["overall_level"] != "Level 1" {
+		t.Errorf("row = %v", row)
+	}
+	if row["caveat"] != testModules[0].Caveat {
+		t.Errorf("caveat = %q, want quotes preserved", row["caveat"])
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTable, testModules); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "CERT") {
+		t.Fatalf("table = %q", buf.String())
+	}
+	// Columns are aligned
+	if strings.Index(lines[1], "Active") != strings.Index(lines[0], "STATUS") {
+		t.Errorf("columns not aligned:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[2], "-") {
+		t.Errorf("missing certificate should be a dash: %q", lines[2])
+	}
+}
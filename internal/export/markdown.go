@@ -0,0 +1,54 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// markdownHeader names the Markdown table columns
+var markdownHeader = []string{
+	"Cert", "Vendor", "Module", "Status", "Level", "Standard", "Embodiment", "Validated", "Sunset", "Caveat",
+}
+
+// WriteMarkdown writes the modules as a Markdown table. Certificate numbers
+// link to their NIST certificate pages.
+func WriteMarkdown(w io.Writer, modules []model.Module) error {
+	if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(markdownHeader, " | ")); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "|%s\n", strings.Repeat("---|", len(markdownHeader))); err != nil {
+		return err
+	}
+	for _, m := range modules {
+		cert := markdownCell(m.CertificateNumber)
+		if cert != "" && m.CertificateURL != "" {
+			cert = fmt.Sprintf("[%s](%s)", cert, m.CertificateURL)
+		}
+		cells := []string{
+			cert,
+			markdownCell(m.VendorName),
+			markdownCell(m.ModuleName),
+			m.Status.String(),
+			markdownCell(m.OverallLevel.String()),
+			markdownCell(m.Standard),
+			markdownCell(m.Embodiment),
+			model.FormatDate(m.ValidationDate),
+			model.FormatDate(m.SunsetDate),
+			markdownCell(m.Caveat),
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+// markdownCell escapes s for a table cell, which must stay on one line
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(strings.TrimSpace(s))
+}
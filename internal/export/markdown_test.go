@@ -0,0 +1,49 @@
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMarkdown(t *testing.T) {
+	modules := append(testModules[:1:1], testModules[1])
+	modules[0].CertificateURL = "https://csrc.nist.gov/certificate/4282"
+	modules[1].Caveat = "Line one\nline | two"
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatMarkdown, modules); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want a header, separator and 2 rows:\n%s", len(lines), buf.String())
+	}
+	for _, want := range []string{"| [4282](https://csrc.nist.gov/certificate/4282) |", "| Level 1 |", "| 2029-07-11 |"} {
+		if !strings.Contains(lines[2], want) {
+			t.Errorf("row %q missing %q", lines[2], want)
+		}
+	}
+	if !strings.Contains(lines[3], `| Line one line \| two |`) {
+		t.Errorf("caveat not escaped: %q", lines[3])
+	}
+}
+
+func TestFormatForPath(t *testing.T) {
+	tests := map[string]Format{
+		"out.csv":           FormatCSV,
+		"/tmp/Modules.JSON": FormatJSON,
+		"out.jsonl":         FormatNDJSON,
+		"report.md":         FormatMarkdown,
+	}
+	for path, want := range tests {
+		if got, err := FormatForPath(path); err != nil || got != want {
+			t.Errorf("FormatForPath(%q) = %q, %v, want %q", path, got, err, want)
+		}
+	}
+	for _, path := range []string{"out", "out.xlsx"} {
+		if _, err := FormatForPath(path); err == nil {
+			t.Errorf("FormatForPath(%q) should fail", path)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WriteYAML writes v as a YAML document, with the keys and omissions of its
+// JSON encoding. String values are always double-quoted, so YAML 1.1
+// parsers cannot misread values such as "yes", "0x1F" or "Note:".
+func WriteYAML(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	// JSON is YAML, and decoding into a node keeps the key order
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	setYAMLStyle(&doc)
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// setYAMLStyle replaces the flow style inherited from JSON with block style,
+// double-quoting string values
+func setYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for i, child := range n.Content {
+		setYAMLStyle(child)
+		isKey := n.Kind == yaml.MappingNode && i%2 == 0
+		if child.Kind == yaml.ScalarNode && child.Tag == "!!str" && !isKey {
+			child.Style = yaml.DoubleQuotedStyle
+		}
+	}
+}
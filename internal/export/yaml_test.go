@@ -0,0 +1,113 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteYAML(t *testing.T) {
+	type area struct {
+		Area  string `json:"area"`
+		Level int    `json:"level"`
+	}
+	type record struct {
+		Cert     string   `json:"cert"`
+		Missing  string   `json:"missing,omitempty"`
+		Empty    []string `json:"empty"`
+		Approved bool     `json:"approved"`
+		Areas    []area   `json:"areas"`
+	}
+
+	v := record{Cert: "4282", Approved: true, Areas: []area{{"Physical Security", 3}, {"Roles", 2}}}
+	want := `cert: "4282"
+empty: null
+approved: true
+areas:
+  - area: "Physical Security"
+    level: 3
+  - area: "Roles"
+    level: 2
+`
+
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, v); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("WriteYAML() =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+// TestWriteYAML_RoundTrip parses the output back and compares it with the
+// JSON encoding of the same value
+func TestWriteYAML_RoundTrip(t *testing.T) {
+	awkward := []string{
+		"Note:", "a: b", "0x1F", "0o17", "=", "~", "<<", "yes", "NO", "y", "null", "1e3", ".inf",
+		"2024-07-12", "- dash", "#comment", "", " padded ", "Line one\nsays \"approved: yes\"", "tab\there",
+	}
+	for _, s := range awkward {
+		mod := testModules[0]
+		mod.Caveat = s
+		mod.ModuleName = s
+		mod.Algorithms = []string{s, "AES"}
+
+		var buf bytes.Buffer
+		if err := WriteYAML(&buf, NewRecord(mod)); err != nil {
+			t.Fatal(err)
+		}
+
+		var fromYAML any
+		if err := yaml.Unmarshal(buf.Bytes(), &fromYAML); err != nil {
+			t.Errorf("%q: output does not parse: %v\n%s", s, err, buf.String())
+			continue
+		}
+		got, err := json.Marshal(fromYAML)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := json.Marshal(NewRecord(mod))
+		if !jsonEqual(t, got, want) {
+			t.Errorf("%q: round trip =\n%s\nwant:\n%s", s, got, want)
+		}
+
+		// Quoting every string keeps YAML 1.1 parsers from reading "yes",
+		// "0x1F" or "=" as another type
+		if s != "" && !strings.Contains(buf.String(), "caveat: \"") {
+			t.Errorf("%q: caveat not double-quoted:\n%s", s, buf.String())
+		}
+	}
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatal(err)
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+func TestWriteYAML_Record(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteYAML(&buf, NewRecord(testModules[0])); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"certificate_number: \"4282\"\n",
+		"overall_level:\n  level: 1\n  text: \"Level 1\"\n",
+		"algorithms_detailed:\n  - raw: \"AES-GCM 128, 256 (A4510)\"\n    family: \"AES\"\n    mode: \"GCM\"\n    key_sizes:\n      - 128\n      - 256\n",
+	} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("YAML missing %q:\n%s", want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package index builds lookup tables over the module dataset
+package index
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// AlgorithmIndex maps normalized algorithm names and CAVP certificate
+// references to the modules that list them
+type AlgorithmIndex struct {
+	modules []model.Module
+	byName  map[string][]int
+	byCAVP  map[string][]int
+}
+
+// AlgorithmCount is an algorithm and the number of modules implementing it
+type AlgorithmCount struct {
+	Name    string
+	Modules int
+}
+
+// NewAlgorithmIndex indexes the algorithms of modules
+func NewAlgorithmIndex(modules []model.Module) *AlgorithmIndex {
+	ix := &AlgorithmIndex{
+		modules: modules,
+		byName:  make(map[string][]int),
+		byCAVP:  make(map[string][]int),
+	}
+	for i, m := range modules {
+		for _, name := range m.AlgorithmNames() {
+			ix.byName[name] = append(ix.byName[name], i)
+		}
+		for _, cert := range m.CAVPCerts() {
+			key := strings.ToUpper(cert)
+			ix.byCAVP[key] = append(ix.byCAVP[key], i)
+		}
+	}
+	return ix
+}
+
+// Algorithms returns every indexed algorithm, most widely implemented first
+func (ix *AlgorithmIndex) Algorithms() []AlgorithmCount {
+	counts := make([]AlgorithmCount, 0, len(ix.byName))
+	for name, mods := range ix.byName {
+		counts = append(counts, AlgorithmCount{Name: name, Modules: len(mods)})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Modules != counts[j].Modules {
+			return counts[i].Modules > counts[j].Modules
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	return counts
+}
+
+// Modules returns the modules implementing an algorithm. The name is
+// normalized first, so "SHA-256" finds modules listing "SHA2-256".
+func (ix *AlgorithmIndex) Modules(algorithm string) []model.Module {
+	return ix.collect(ix.byName[model.NormalizeAlgorithm(algorithm)])
+}
+
+// CAVP returns the modules whose algorithms cite a CAVP certificate such as
+// "A1234" or "AES #4567"
+func (ix *AlgorithmIndex) CAVP(cert string) []model.Module {
+	return ix.collect(ix.byCAVP[strings.ToUpper(strings.TrimSpace(cert))])
+}
+
+// Lookup finds modules by CAVP certificate if term looks like one, and by
+// algorithm otherwise
+func (ix *AlgorithmIndex) Lookup(term string) []model.Module {
+	if model.IsCAVPCert(term) || strings.Contains(term, "#") {
+		return ix.CAVP(term)
+	}
+	return ix.Modules(term)
+}
+
+func (ix *AlgorithmIndex) collect(indexes []int) []model.Module {
+	if len(indexes) == 0 {
+		return nil
+	}
+	modules := make([]model.Module, len(indexes))
+	for i, idx := range indexes {
+		modules[i] = ix.modules[idx]
+	}
+	return modules
+}
@@ -0,0 +1,72 @@
+package index
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+var testModules = []model.Module{
+	{
+		CertificateNumber:  "1",
+		Algorithms:         []string{"AES", "SHS"},
+		AlgorithmsDetailed: []string{"AES-GCM (A100)", "SHA-256 (A100)"},
+	},
+	{
+		CertificateNumber:  "2",
+		Algorithms:         []string{"AES", "ML-KEM"},
+		AlgorithmsDetailed: []string{"AES-CBC (Cert. #4567)", "ML-KEM (A200)"},
+	},
+	{
+		CertificateNumber: "3",
+		Algorithms:        []string{"RSA"},
+	},
+}
+
+func certNumbers(modules []model.Module) []string {
+	var certs []string
+	for _, m := range modules {
+		certs = append(certs, m.CertificateNumber)
+	}
+	return certs
+}
+
+func TestAlgorithmIndex_Algorithms(t *testing.T) {
+	ix := NewAlgorithmIndex(testModules)
+	want := []AlgorithmCount{
+		{Name: "AES", Modules: 2},
+		{Name: "ML-KEM", Modules: 1},
+		{Name: "RSA", Modules: 1},
+		{Name: "SHA2-256", Modules: 1},
+		{Name: "SHS", Modules: 1},
+	}
+	if got := ix.Algorithms(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Algorithms() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAlgorithmIndex_Lookup(t *testing.T) {
+	ix := NewAlgorithmIndex(testModules)
+
+	tests := []struct {
+		term string
+		want []string
+	}{
+		{"aes", []string{"1", "2"}},
+		{"SHA-256", []string{"1"}},
+		{"Kyber", []string{"2"}},
+		{"a100", []string{"1"}},
+		{"A200", []string{"2"}},
+		{"AES #4567", []string{"2"}},
+		{"ECDSA", nil},
+		{"A999", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			if got := certNumbers(ix.Lookup(tt.term)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%q) = %v, want %v", tt.term, got, tt.want)
+			}
+		})
+	}
+}
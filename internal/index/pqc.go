@@ -0,0 +1,121 @@
+package index
+
+import (
+	"sort"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// PQCAlgorithm is a post-quantum algorithm and the standard that specifies it
+type PQCAlgorithm struct {
+	Name     string // Normalized algorithm name
+	Standard string
+}
+
+// PQCAlgorithms lists the post-quantum algorithms tracked by the PQC report,
+// FIPS 203, 204 and 205 first
+var PQCAlgorithms = []PQCAlgorithm{
+	{Name: "ML-KEM", Standard: "FIPS 203"},
+	{Name: "ML-DSA", Standard: "FIPS 204"},
+	{Name: "SLH-DSA", Standard: "FIPS 205"},
+	{Name: "LMS", Standard: "SP 800-208"},
+	{Name: "XMSS", Standard: "SP 800-208"},
+	{Name: "FN-DSA", Standard: "FIPS 206 (draft)"},
+}
+
+// fipsPQC are the algorithms of the finalized PQC FIPS standards
+var fipsPQC = []string{"ML-KEM", "ML-DSA", "SLH-DSA"}
+
+// PQCGroup is a post-quantum algorithm and the modules implementing it,
+// most recently validated first
+type PQCGroup struct {
+	PQCAlgorithm
+	Modules []model.Module
+}
+
+// Count returns how many modules in the group have status
+func (g PQCGroup) Count(status model.ModuleStatus) int {
+	n := 0
+	for _, m := range g.Modules {
+		if m.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Vendors returns how many distinct vendors have a module in the group
+func (g PQCGroup) Vendors() int {
+	return countVendors(g.Modules)
+}
+
+// PQCReport summarizes post-quantum algorithm adoption across modules
+type PQCReport struct {
+	Groups  []PQCGroup     // One group per PQCAlgorithms entry, in the same order
+	Modules []model.Module // Modules implementing any PQC algorithm
+	// FullFIPS are the modules implementing ML-KEM, ML-DSA and SLH-DSA
+	// (FIPS 203, 204 and 205)
+	FullFIPS []model.Module
+}
+
+// Vendors returns how many distinct vendors have a module with a PQC algorithm
+func (r PQCReport) Vendors() int {
+	return countVendors(r.Modules)
+}
+
+// PQC groups the indexed modules by post-quantum algorithm
+func (ix *AlgorithmIndex) PQC() PQCReport {
+	var report PQCReport
+	seen := make(map[int]bool)
+	fips := make(map[int]int)
+	for _, alg := range PQCAlgorithms {
+		indexes := ix.byName[alg.Name]
+		for _, i := range indexes {
+			if !seen[i] {
+				seen[i] = true
+				report.Modules = append(report.Modules, ix.modules[i])
+			}
+		}
+		for _, name := range fipsPQC {
+			if alg.Name == name {
+				for _, i := range indexes {
+					fips[i]++
+				}
+			}
+		}
+		modules := ix.collect(indexes)
+		sortByValidation(modules)
+		report.Groups = append(report.Groups, PQCGroup{PQCAlgorithm: alg, Modules: modules})
+	}
+	for i, n := range fips {
+		if n == len(fipsPQC) {
+			report.FullFIPS = append(report.FullFIPS, ix.modules[i])
+		}
+	}
+	sortByValidation(report.Modules)
+	sortByValidation(report.FullFIPS)
+	return report
+}
+
+// sortByValidation orders modules most recently validated first. Modules
+// without a validation date, such as those in process, come last.
+func sortByValidation(modules []model.Module) {
+	sort.SliceStable(modules, func(i, j int) bool {
+		a, b := modules[i].ValidationDate, modules[j].ValidationDate
+		if a.IsZero() != b.IsZero() {
+			return b.IsZero()
+		}
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return modules[i].ModuleName < modules[j].ModuleName
+	})
+}
+
+func countVendors(modules []model.Module) int {
+	vendors := make(map[string]bool)
+	for _, m := range modules {
+		vendors[m.VendorName] = true
+	}
+	return len(vendors)
+}
@@ -0,0 +1,49 @@
+package index
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestAlgorithmIndex_PQC(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	modules := []model.Module{
+		{CertificateNumber: "1", VendorName: "A", Algorithms: []string{"ML-KEM", "AES"}, ValidationDate: date("2024-01-02")},
+		{CertificateNumber: "2", VendorName: "B", Algorithms: []string{"ML-KEM", "ML-DSA", "SLH-DSA"}, ValidationDate: date("2025-03-04")},
+		{CertificateNumber: "3", VendorName: "A", Algorithms: []string{"CRYSTALS-Kyber"}, Status: model.StatusInProcess},
+		{CertificateNumber: "4", VendorName: "C", Algorithms: []string{"LMS"}, ValidationDate: date("2023-05-06")},
+		{CertificateNumber: "5", VendorName: "D", Algorithms: []string{"RSA"}},
+	}
+
+	report := NewAlgorithmIndex(modules).PQC()
+	if len(report.Groups) != len(PQCAlgorithms) {
+		t.Fatalf("got %d groups, want one per PQC algorithm", len(report.Groups))
+	}
+
+	mlkem := report.Groups[0]
+	if mlkem.Name != "ML-KEM" || mlkem.Standard != "FIPS 203" {
+		t.Errorf("first group = %+v, want ML-KEM (FIPS 203)", mlkem.PQCAlgorithm)
+	}
+	if got := certNumbers(mlkem.Modules); !reflect.DeepEqual(got, []string{"2", "1", "3"}) {
+		t.Errorf("ML-KEM modules = %v, want newest first and in process last", got)
+	}
+	if mlkem.Count(model.StatusInProcess) != 1 || mlkem.Vendors() != 2 {
+		t.Errorf("ML-KEM in process = %d, vendors = %d, want 1 and 2", mlkem.Count(model.StatusInProcess), mlkem.Vendors())
+	}
+
+	if got := certNumbers(report.Modules); !reflect.DeepEqual(got, []string{"2", "1", "4", "3"}) {
+		t.Errorf("PQC modules = %v", got)
+	}
+	if report.Vendors() != 3 {
+		t.Errorf("Vendors() = %d, want 3", report.Vendors())
+	}
+	if got := certNumbers(report.FullFIPS); !reflect.DeepEqual(got, []string{"2"}) {
+		t.Errorf("FullFIPS = %v, want [2]", got)
+	}
+}
@@ -0,0 +1,245 @@
+package model
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// algorithmRules map algorithm spellings onto normalized names. Rules are
+// tried in order, so more specific names (HMAC_DRBG) come before the
+// names they contain (HMAC, SHA).
+var algorithmRules = []struct {
+	contains []string
+	name     string
+}{
+	{[]string{"ML-KEM", "MLKEM", "KYBER"}, "ML-KEM"},
+	{[]string{"ML-DSA", "MLDSA", "DILITHIUM"}, "ML-DSA"},
+	{[]string{"SLH-DSA", "SLHDSA", "SPHINCS"}, "SLH-DSA"},
+	{[]string{"FN-DSA", "FNDSA", "FALCON"}, "FN-DSA"},
+	{[]string{"XMSS"}, "XMSS"},
+	{[]string{"LMS", "HSS"}, "LMS"},
+	{[]string{"DRBG"}, "DRBG"},
+	{[]string{"HMAC"}, "HMAC"},
+	{[]string{"CMAC"}, "CMAC"},
+	{[]string{"KMAC"}, "KMAC"},
+	{[]string{"SHAKE"}, "SHAKE"},
+	{[]string{"ECDSA"}, "ECDSA"},
+	{[]string{"EDDSA"}, "EdDSA"},
+	{[]string{"KAS"}, "KAS"},
+	{[]string{"KTS"}, "KTS"},
+	{[]string{"KDF", "KDA"}, "KDF"},
+	{[]string{"TDES", "TRIPLE-DES", "3DES", "TDEA"}, "TDES"},
+	{[]string{"AES"}, "AES"},
+	{[]string{"RSA"}, "RSA"},
+	{[]string{"DSA"}, "DSA"},
+	{[]string{"SHS"}, "SHS"},
+}
+
+var (
+	// shaDigestRe matches SHA-2 digests named without a version: SHA-256, SHA-512/256
+	shaDigestRe = regexp.MustCompile(`SHA-?(\d{3}(?:/\d{3})?)`)
+	// shaVersionRe matches SHA-1, SHA2-384, SHA3-256 and bare SHA versions
+	shaVersionRe = regexp.MustCompile(`SHA-?([123])(?:-(\d{3}(?:/\d{3})?))?`)
+)
+
+// NormalizeAlgorithm maps an algorithm name or detailed algorithm entry such
+// as "AES-GCM (A1234)", "SHA-256" or "CRYSTALS-Kyber" onto a normalized name
+// such as "AES", "SHA2-256" or "ML-KEM"
+func NormalizeAlgorithm(s string) string {
+	upper := strings.ToUpper(strings.TrimSpace(s))
+	upper = strings.NewReplacer("_", "-", " ", "-").Replace(upper)
+	if upper == "" {
+		return ""
+	}
+
+	for _, rule := range algorithmRules {
+		for _, c := range rule.contains {
+			if strings.Contains(upper, c) {
+				return rule.name
+			}
+		}
+	}
+
+	if m := shaDigestRe.FindStringSubmatch(upper); m != nil {
+		return "SHA2-" + m[1]
+	}
+	if m := shaVersionRe.FindStringSubmatch(upper); m != nil {
+		version, digest := m[1], m[2]
+		switch {
+		case version == "1":
+			return "SHA-1"
+		case digest == "":
+			return "SHA" + version
+		default:
+			return "SHA" + version + "-" + digest
+		}
+	}
+
+	// Fall back to the first word, e.g. "ENT (P)" becomes "ENT"
+	if i := strings.IndexAny(upper, "-:(,"); i > 0 {
+		return upper[:i]
+	}
+	return upper
+}
+
+// AlgorithmNames returns the normalized names of every algorithm the module
+// lists, sorted and without duplicates
+func (m Module) AlgorithmNames() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, list := range [][]string{m.Algorithms, m.AlgorithmsDetailed} {
+		for _, algo := range list {
+			if name := NormalizeAlgorithm(algo); name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+var (
+	// acvpCertRe matches ACVP validation numbers such as A1234 or C567
+	acvpCertRe = regexp.MustCompile(`\b([AC]\d{1,6})\b`)
+	// cavpCertRe matches a whole ACVP validation number
+	cavpCertRe = regexp.MustCompile(`(?i)^[AC]\d{1,6}$`)
+	// legacyCertRe matches older CAVP references such as "Cert. #4567"
+	legacyCertRe = regexp.MustCompile(`(?i)cert\.?\s*#\s*(\d+)`)
+)
+
+// CAVPCerts returns the algorithm validation certificates referenced by the
+// module's detailed algorithm list. ACVP numbers such as "A1234" are unique;
+// older numeric certificates are only unique per algorithm, so they are
+// returned as e.g. "AES #4567".
+func (m Module) CAVPCerts() []string {
+	seen := make(map[string]bool)
+	var certs []string
+	add := func(ref string) {
+		if !seen[ref] {
+			seen[ref] = true
+			certs = append(certs, ref)
+		}
+	}
+
+	for _, algo := range m.AlgorithmsDetailed {
+		for _, match := range acvpCertRe.FindAllStringSubmatch(algo, -1) {
+			add(match[1])
+		}
+		for _, match := range legacyCertRe.FindAllStringSubmatch(algo, -1) {
+			add(NormalizeAlgorithm(algo) + " #" + match[1])
+		}
+	}
+	sort.Strings(certs)
+	return certs
+}
+
+// IsCAVPCert reports whether s is an ACVP validation number such as A1234
+func IsCAVPCert(s string) bool {
+	return cavpCertRe.MatchString(strings.TrimSpace(s))
+}
+
+// AlgorithmEntry is a structured form of one AlgorithmsDetailed entry
+type AlgorithmEntry struct {
+	Raw      string `json:"raw"`
+	Family   string `json:"family"`              // Normalized name, e.g. "AES"
+	Mode     string `json:"mode,omitempty"`      // e.g. "GCM", "SigGen", "CTR_DRBG"
+	KeySizes []int  `json:"key_sizes,omitempty"` // Key or modulus sizes in bits
+	CAVPCert string `json:"cavp_cert,omitempty"` // e.g. "A1234", or "#4567" for older certs
+	Approved bool   `json:"approved"`
+}
+
+// algorithmModes are modes and functions recognized in algorithm entries,
+// matched as whole words and reported in this spelling
+var algorithmModes = []string{
+	"ECB", "CBC", "CBC-CS1", "CBC-CS2", "CBC-CS3", "CFB1", "CFB8", "CFB128", "OFB", "CTR",
+	"GCM", "GCM-SIV", "GMAC", "CCM", "XTS", "KW", "KWP", "FF1", "FF3-1", "CMAC",
+	"CTR_DRBG", "Hash_DRBG", "HMAC_DRBG",
+	"KeyGen", "SigGen", "SigVer", "KeyVer", "PQGGen", "PQGVer", "Encap", "Decap",
+	"Component", "SSC", "OneStep", "TwoStep", "HKDF", "PBKDF", "KBKDF", "TLS", "SSH", "IKEv2", "SRTP",
+}
+
+var (
+	// wordRe splits an entry into words for mode matching
+	wordRe = regexp.MustCompile(`[A-Za-z0-9_]+(?:-[A-Za-z0-9]+)*`)
+	// keySizeRe matches candidate key sizes
+	keySizeRe = regexp.MustCompile(`\b(\d{3,5})(?:-bit)?\b`)
+	// digestRe matches digest and document names whose numbers are not key
+	// sizes, such as SHA2-256, SHA-512/256, FIPS186-4 and SP800-56A
+	digestRe = regexp.MustCompile(`(?i)(?:SHA\d?-?\d{3}(?:/\d{3})?|SHAKE-?\d{3}|FIPS\s?\d{3}(?:-\d)?|SP\s?800-\d+\w*)`)
+	// nonApprovedRe matches entries marked as not approved
+	nonApprovedRe = regexp.MustCompile(`(?i)\b(?:non-?\s?approved|not\s+approved|allowed)\b`)
+)
+
+// keySizes are the key, modulus and curve sizes CMVP certificates list
+var keySizes = map[int]bool{
+	112: true, 128: true, 168: true, 192: true, 224: true, 256: true, 384: true, 512: true, 521: true,
+	1024: true, 2048: true, 3072: true, 4096: true, 6144: true, 8192: true, 15360: true,
+}
+
+// ParseAlgorithm parses a free-form AlgorithmsDetailed entry such as
+// "AES-GCM (128, 256) A1234" into its family, mode, key sizes, CAVP
+// certificate and approval status. Anything it does not recognize is left
+// out; Raw keeps the original text.
+func ParseAlgorithm(s string) AlgorithmEntry {
+	entry := AlgorithmEntry{
+		Raw:      strings.TrimSpace(s),
+		Family:   NormalizeAlgorithm(s),
+		Approved: !nonApprovedRe.MatchString(s),
+	}
+
+	for _, word := range wordRe.FindAllString(s, -1) {
+		if mode := matchMode(word); mode != "" {
+			entry.Mode = mode
+			break
+		}
+	}
+
+	if m := acvpCertRe.FindStringSubmatch(s); m != nil {
+		entry.CAVPCert = m[1]
+	} else if m := legacyCertRe.FindStringSubmatch(s); m != nil {
+		entry.CAVPCert = "#" + m[1]
+	}
+
+	seen := make(map[int]bool)
+	for _, m := range keySizeRe.FindAllStringSubmatch(digestRe.ReplaceAllString(s, " "), -1) {
+		n, _ := strconv.Atoi(m[1])
+		if keySizes[n] && !seen[n] {
+			seen[n] = true
+			entry.KeySizes = append(entry.KeySizes, n)
+		}
+	}
+	sort.Ints(entry.KeySizes)
+	return entry
+}
+
+// matchMode returns the mode a word names, also accepting a mode joined to
+// other names as in "AES-GCM" or "KAS-ECC-SSC"
+func matchMode(word string) string {
+	candidates := []string{word}
+	for i, c := range word {
+		if c == '-' {
+			candidates = append(candidates, word[i+1:])
+		}
+	}
+	candidates = append(candidates, strings.Split(word, "-")...)
+	for _, c := range candidates {
+		for _, mode := range algorithmModes {
+			if strings.EqualFold(c, mode) {
+				return mode
+			}
+		}
+	}
+	return ""
+}
+
+// AlgorithmEntries parses every entry in the module's AlgorithmsDetailed
+func (m Module) AlgorithmEntries() []AlgorithmEntry {
+	entries := make([]AlgorithmEntry, len(m.AlgorithmsDetailed))
+	for i, s := range m.AlgorithmsDetailed {
+		entries[i] = ParseAlgorithm(s)
+	}
+	return entries
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeAlgorithm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"AES", "AES"},
+		{"AES-GCM (A1234)", "AES"},
+		{"aes_cbc", "AES"},
+		{"SHA-1", "SHA-1"},
+		{"SHA-256", "SHA2-256"},
+		{"SHA2-384", "SHA2-384"},
+		{"SHA-512/256", "SHA2-512/256"},
+		{"SHA3-256 (A2)", "SHA3-256"},
+		{"SHA-3", "SHA3"},
+		{"SHAKE-128", "SHAKE"},
+		{"HMAC-SHA2-256", "HMAC"},
+		{"HMAC_DRBG", "DRBG"},
+		{"Counter DRBG", "DRBG"},
+		{"ECDSA SigGen (FIPS186-4)", "ECDSA"},
+		{"EdDSA", "EdDSA"},
+		{"DSA", "DSA"},
+		{"RSA SigVer", "RSA"},
+		{"ML-KEM (FIPS 203)", "ML-KEM"},
+		{"CRYSTALS-Kyber", "ML-KEM"},
+		{"ML-DSA keyGen", "ML-DSA"},
+		{"CRYSTALS-Dilithium", "ML-DSA"},
+		{"SLH-DSA", "SLH-DSA"},
+		{"SPHINCS+", "SLH-DSA"},
+		{"Falcon-512", "FN-DSA"},
+		{"LMS SigVer", "LMS"},
+		{"XMSS", "XMSS"},
+		{"KAS-ECC-SSC Sp800-56Ar3", "KAS"},
+		{"KDF SP800-108", "KDF"},
+		{"Triple-DES", "TDES"},
+		{"ENT (P)", "ENT"},
+		{"  ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := NormalizeAlgorithm(tt.input); got != tt.want {
+				t.Errorf("NormalizeAlgorithm(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_AlgorithmNames(t *testing.T) {
+	m := Module{
+		Algorithms:         []string{"AES", "SHS"},
+		AlgorithmsDetailed: []string{"AES-GCM (A1)", "AES-CBC (A1)", "SHA-256 (A1)", "ML-KEM (A2)"},
+	}
+	want := []string{"AES", "ML-KEM", "SHA2-256", "SHS"}
+	if got := m.AlgorithmNames(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AlgorithmNames() = %v, want %v", got, want)
+	}
+}
+
+func TestModule_CAVPCerts(t *testing.T) {
+	m := Module{
+		AlgorithmsDetailed: []string{
+			"AES-GCM (A1234)",
+			"SHA-256: A1234, C56",
+			"AES (Cert. #4567)",
+			"Triple-DES (Cert #89)",
+			"RSA",
+		},
+	}
+	want := []string{"A1234", "AES #4567", "C56", "TDES #89"}
+	if got := m.CAVPCerts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CAVPCerts() = %v, want %v", got, want)
+	}
+}
+
+func TestIsCAVPCert(t *testing.T) {
+	for s, want := range map[string]bool{"A1234": true, "c56": true, " A1 ": true, "AES": false, "A1234x": false, "1234": false} {
+		if got := IsCAVPCert(s); got != want {
+			t.Errorf("IsCAVPCert(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	tests := []struct {
+		input string
+		want  AlgorithmEntry
+	}{
+		{
+			input: "AES-GCM (128, 192, 256) A1234",
+			want:  AlgorithmEntry{Family: "AES", Mode: "GCM", KeySizes: []int{128, 192, 256}, CAVPCert: "A1234", Approved: true},
+		},
+		{
+			input: "AES-128",
+			want:  AlgorithmEntry{Family: "AES", KeySizes: []int{128}, Approved: true},
+		},
+		{
+			input: "RSA SigGen (FIPS186-4) 2048, 3072, 4096 with SHA2-256 (A2345)",
+			want:  AlgorithmEntry{Family: "RSA", Mode: "SigGen", KeySizes: []int{2048, 3072, 4096}, CAVPCert: "A2345", Approved: true},
+		},
+		{
+			input: "HMAC-SHA2-256 (Cert. #4567)",
+			want:  AlgorithmEntry{Family: "HMAC", CAVPCert: "#4567", Approved: true},
+		},
+		{
+			input: "Counter DRBG: CTR_DRBG AES-256 C12",
+			want:  AlgorithmEntry{Family: "DRBG", Mode: "CTR_DRBG", KeySizes: []int{256}, CAVPCert: "C12", Approved: true},
+		},
+		{
+			input: "ECDSA KeyGen P-256, P-384 (FIPS 186-4)",
+			want:  AlgorithmEntry{Family: "ECDSA", Mode: "KeyGen", KeySizes: []int{256, 384}, Approved: true},
+		},
+		{
+			input: "KAS-ECC-SSC Sp800-56Ar3 (A3)",
+			want:  AlgorithmEntry{Family: "KAS", Mode: "SSC", CAVPCert: "A3", Approved: true},
+		},
+		{
+			input: "MD5 (non-approved)",
+			want:  AlgorithmEntry{Family: "MD5", Approved: false},
+		},
+		{
+			input: "RSA 1024 key wrapping - allowed",
+			want:  AlgorithmEntry{Family: "RSA", KeySizes: []int{1024}, Approved: false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ParseAlgorithm(tt.input)
+			tt.want.Raw = tt.input
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAlgorithm() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModule_AlgorithmEntries(t *testing.T) {
+	m := Module{AlgorithmsDetailed: []string{"AES-CBC (A1)", "SHA-256 (A1)"}}
+	entries := m.AlgorithmEntries()
+	if len(entries) != 2 || entries[0].Mode != "CBC" || entries[1].Family != "SHA2-256" {
+		t.Errorf("AlgorithmEntries() = %+v", entries)
+	}
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SecurityLevel is a module's overall FIPS 140 security level. Most modules
+// publish a number, but some publish text such as "Tested Configuration(s)"
+// or list a level per security area instead.
+type SecurityLevel struct {
+	Level int         // Overall level 1-4, or 0 if none was published
+	Raw   string      // Value exactly as published, e.g. "2"
+	Areas []AreaLevel // Per-area levels, if the published value lists them
+}
+
+// AreaLevel is the level achieved in one FIPS 140 security area
+type AreaLevel struct {
+	Area  string
+	Level int
+}
+
+// areaLevelRe matches "Physical Security: 3" and "Physical Security Level 3"
+var areaLevelRe = regexp.MustCompile(`(?i)^(.*?[a-z)])\s*(?::|-|\blevel\b)\s*(\d)$`)
+
+// levelRe matches a bare overall level such as "2" or "Level 2"
+var levelRe = regexp.MustCompile(`(?i)^(?:level\s*)?(\d)$`)
+
+// ParseSecurityLevel parses a published overall level
+func ParseSecurityLevel(raw string) SecurityLevel {
+	raw = strings.TrimSpace(raw)
+	level := SecurityLevel{Raw: raw}
+	if m := levelRe.FindStringSubmatch(raw); m != nil {
+		level.Level, _ = strconv.Atoi(m[1])
+		return level
+	}
+
+	for _, line := range strings.FieldsFunc(raw, func(r rune) bool { return r == '\n' || r == ';' }) {
+		if m := areaLevelRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			n, _ := strconv.Atoi(m[2])
+			level.Areas = append(level.Areas, AreaLevel{Area: strings.TrimSpace(m[1]), Level: n})
+		}
+	}
+	return level
+}
+
+// NewSecurityLevel returns a numeric overall level. Zero means no level.
+func NewSecurityLevel(level int) SecurityLevel {
+	if level == 0 {
+		return SecurityLevel{}
+	}
+	return SecurityLevel{Level: level, Raw: strconv.Itoa(level)}
+}
+
+// IsZero reports whether no level was published
+func (l SecurityLevel) IsZero() bool {
+	return l.Level == 0 && l.Raw == "" && len(l.Areas) == 0
+}
+
+// AreaRange returns the lowest and highest per-area levels
+func (l SecurityLevel) AreaRange() (lowest, highest int) {
+	for i, a := range l.Areas {
+		if i == 0 || a.Level < lowest {
+			lowest = a.Level
+		}
+		if a.Level > highest {
+			highest = a.Level
+		}
+	}
+	return lowest, highest
+}
+
+// String returns a short display form: "Level 2", "Levels 1-3" for
+// per-area levels, or the published text
+func (l SecurityLevel) String() string {
+	switch {
+	case l.Level > 0:
+		return fmt.Sprintf("Level %d", l.Level)
+	case len(l.Areas) > 0:
+		lowest, highest := l.AreaRange()
+		if lowest == highest {
+			return fmt.Sprintf("Level %d (all areas)", lowest)
+		}
+		return fmt.Sprintf("Levels %d-%d", lowest, highest)
+	default:
+		return l.Raw
+	}
+}
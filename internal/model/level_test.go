@@ -0,0 +1,81 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSecurityLevel(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		wantLevel  int
+		wantAreas  []AreaLevel
+		wantString string
+	}{
+		{
+			name:       "numeric",
+			raw:        "2",
+			wantLevel:  2,
+			wantString: "Level 2",
+		},
+		{
+			name:       "level prefix",
+			raw:        " Level 3 ",
+			wantLevel:  3,
+			wantString: "Level 3",
+		},
+		{
+			name:       "text",
+			raw:        "Tested Configuration(s)",
+			wantString: "Tested Configuration(s)",
+		},
+		{
+			name: "per-area levels",
+			raw:  "Physical Security: 3\nRoles, Services, and Authentication: 2; Design Assurance Level 3",
+			wantAreas: []AreaLevel{
+				{Area: "Physical Security", Level: 3},
+				{Area: "Roles, Services, and Authentication", Level: 2},
+				{Area: "Design Assurance", Level: 3},
+			},
+			wantString: "Levels 2-3",
+		},
+		{
+			name:       "per-area levels all equal",
+			raw:        "Physical Security - 1\nSoftware Security - 1",
+			wantAreas:  []AreaLevel{{Area: "Physical Security", Level: 1}, {Area: "Software Security", Level: 1}},
+			wantString: "Level 1 (all areas)",
+		},
+		{
+			name: "empty",
+			raw:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseSecurityLevel(tt.raw)
+			if got.Level != tt.wantLevel {
+				t.Errorf("Level = %d, want %d", got.Level, tt.wantLevel)
+			}
+			if !reflect.DeepEqual(got.Areas, tt.wantAreas) {
+				t.Errorf("Areas = %+v, want %+v", got.Areas, tt.wantAreas)
+			}
+			if got.String() != tt.wantString {
+				t.Errorf("String() = %q, want %q", got.String(), tt.wantString)
+			}
+		})
+	}
+}
+
+func TestSecurityLevel_IsZero(t *testing.T) {
+	if !NewSecurityLevel(0).IsZero() {
+		t.Error("expected NewSecurityLevel(0) to be zero")
+	}
+	if NewSecurityLevel(1).IsZero() {
+		t.Error("expected level 1 not to be zero")
+	}
+	if ParseSecurityLevel("Tested Configuration(s)").IsZero() {
+		t.Error("expected a text level not to be zero")
+	}
+}
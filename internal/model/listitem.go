@@ -15,9 +15,19 @@ func (m ModuleItem) Title() string {
 	return m.ModuleName
 }
 
-// Description returns secondary display text (Vendor + Type + Status)
+// Description returns secondary display text (Vendor + Type + Status).
+// In-process modules usually have no type yet, so they show their standard
+// and review stage instead.
 func (m ModuleItem) Description() string {
-	return fmt.Sprintf("%s | %s | %s", m.VendorName, m.ModuleType, m.Status.String())
+	moduleType := m.ModuleType
+	if moduleType == "" {
+		moduleType = m.Standard
+	}
+	status := m.Status.String()
+	if m.Stage != "" {
+		status += ": " + string(m.Stage)
+	}
+	return fmt.Sprintf("%s | %s | %s", m.VendorName, moduleType, status)
 }
 
 // FilterValue returns the string used for filtering
@@ -60,6 +60,18 @@ func TestModuleItem_Description(t *testing.T) {
 			},
 			contains: []string{"New Vendor", "Firmware", "In Process"},
 		},
+		{
+			name: "in process module with stage",
+			item: ModuleItem{
+				Module: Module{
+					VendorName: "Queued Vendor",
+					Standard:   "FIPS 140-3",
+					Status:     StatusInProcess,
+					Stage:      StageCoordination,
+				},
+			},
+			contains: []string{"Queued Vendor", "FIPS 140-3", "In Process: Coordination"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -99,6 +111,24 @@ func TestModuleItem_FilterValue(t *testing.T) {
 	}
 }
 
+func TestModuleItem_FilterValue_ExcludesLevelAndStage(t *testing.T) {
+	item := ModuleItem{
+		Module: Module{
+			ModuleName:   "Test Module",
+			OverallLevel: NewSecurityLevel(2),
+			Status:       StatusInProcess,
+			Stage:        StageReviewPending,
+		},
+	}
+
+	// Free text would otherwise match "level" or "2" on almost every
+	// module; the level: and stage: query fields search these instead
+	got := item.FilterValue()
+	if strings.Contains(got, "Level") || strings.Contains(got, "Review Pending") {
+		t.Errorf("FilterValue() = %q, want only certificate, name and vendor", got)
+	}
+}
+
 func TestModuleItem_FilterValue_EmptyFields(t *testing.T) {
 	item := ModuleItem{
 		Module: Module{
@@ -1,6 +1,9 @@
 package model
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // ModuleStatus represents the validation status of a module
 type ModuleStatus int
@@ -24,6 +27,48 @@ func (s ModuleStatus) String() string {
 	}
 }
 
+// ProcessStage is where an in-process module sits in the CMVP review queue.
+// Stages CMVP has not used before are kept verbatim.
+type ProcessStage string
+
+const (
+	StageReviewPending ProcessStage = "Review Pending"
+	StageInReview      ProcessStage = "In Review"
+	StageCoordination  ProcessStage = "Coordination"
+	StageFinalization  ProcessStage = "Finalization"
+)
+
+// processStages lists the known stages in queue order
+var processStages = []ProcessStage{
+	StageReviewPending,
+	StageInReview,
+	StageCoordination,
+	StageFinalization,
+}
+
+// ParseProcessStage maps a published in-process status onto a known stage,
+// ignoring case and spacing
+func ParseProcessStage(s string) ProcessStage {
+	s = strings.Join(strings.Fields(s), " ")
+	for _, stage := range processStages {
+		if strings.EqualFold(s, string(stage)) {
+			return stage
+		}
+	}
+	return ProcessStage(s)
+}
+
+// Rank returns the stage's position in the queue, starting at 1, or 0 for
+// an unknown stage
+func (s ProcessStage) Rank() int {
+	for i, stage := range processStages {
+		if s == stage {
+			return i + 1
+		}
+	}
+	return 0
+}
+
 // Module represents a NIST CMVP cryptographic module
 type Module struct {
 	CertificateNumber string
@@ -33,16 +78,48 @@ type Module struct {
 	ModuleType        string
 	ValidationDate    time.Time
 	Status            ModuleStatus
+	Stage             ProcessStage // Review stage of an in-process module
 
 	// Extended fields from certificate detail extraction
-	Standard          string
-	OverallLevel      int
-	SunsetDate        string
-	Caveat            string
-	Embodiment        string
-	Description       string
+	Standard           string
+	OverallLevel       SecurityLevel
+	SunsetDate         time.Time
+	Caveat             string
+	Embodiment         string
+	Description        string
 	Lab                string
 	Algorithms         []string
 	AlgorithmsDetailed []string
 	SecurityPolicyURL  string
 }
+
+// Key identifies the module across reloads. In-process modules have no
+// certificate number yet, so they are keyed by vendor and name.
+func (m Module) Key() string {
+	if m.CertificateNumber != "" {
+		return m.CertificateNumber
+	}
+	return m.VendorName + "\x00" + m.ModuleName
+}
+
+// FormatDate formats t as YYYY-MM-DD, or "" if it is zero
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+// DaysUntilSunset returns the number of calendar days from now until the
+// module's sunset date, negative once it has passed. ok is false if the
+// module has no sunset date.
+func (m Module) DaysUntilSunset(now time.Time) (days int, ok bool) {
+	if m.SunsetDate.IsZero() {
+		return 0, false
+	}
+	y, mo, d := now.Date()
+	today := time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+	y, mo, d = m.SunsetDate.Date()
+	sunset := time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+	return int(sunset.Sub(today).Hours() / 24), true
+}
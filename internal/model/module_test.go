@@ -1,6 +1,9 @@
 package model
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestModuleStatus_String(t *testing.T) {
 	tests := []struct {
@@ -38,3 +41,81 @@ func TestModuleStatus_String(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatDate(t *testing.T) {
+	if got := FormatDate(time.Date(2026, 3, 1, 15, 4, 0, 0, time.UTC)); got != "2026-03-01" {
+		t.Errorf("FormatDate = %q, want 2026-03-01", got)
+	}
+	if got := FormatDate(time.Time{}); got != "" {
+		t.Errorf("FormatDate(zero) = %q, want empty", got)
+	}
+}
+
+func TestModule_DaysUntilSunset(t *testing.T) {
+	now := time.Date(2026, 10, 17, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		sunset   time.Time
+		wantDays int
+		wantOK   bool
+	}{
+		{
+			name:   "no sunset date",
+			wantOK: false,
+		},
+		{
+			name:     "today",
+			sunset:   time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC),
+			wantDays: 0,
+			wantOK:   true,
+		},
+		{
+			name:     "future",
+			sunset:   time.Date(2027, 1, 15, 0, 0, 0, 0, time.UTC),
+			wantDays: 90,
+			wantOK:   true,
+		},
+		{
+			name:     "passed",
+			sunset:   time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC),
+			wantDays: -7,
+			wantOK:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, ok := Module{SunsetDate: tt.sunset}.DaysUntilSunset(now)
+			if days != tt.wantDays || ok != tt.wantOK {
+				t.Errorf("DaysUntilSunset() = %d, %v, want %d, %v", days, ok, tt.wantDays, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestParseProcessStage(t *testing.T) {
+	tests := []struct {
+		input    string
+		want     ProcessStage
+		wantRank int
+	}{
+		{"Review Pending", StageReviewPending, 1},
+		{"in  review", StageInReview, 2},
+		{" COORDINATION ", StageCoordination, 3},
+		{"Finalization", StageFinalization, 4},
+		{"On Hold", ProcessStage("On Hold"), 0},
+		{"", ProcessStage(""), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got := ParseProcessStage(tt.input)
+			if got != tt.want {
+				t.Errorf("ParseProcessStage(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if got.Rank() != tt.wantRank {
+				t.Errorf("Rank() = %d, want %d", got.Rank(), tt.wantRank)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// fieldKind determines which operators and values a field accepts
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindStatus
+	kindLevel
+	kindCert
+	kindDate
+	kindAlgo
+	kindCAVP
+)
+
+// field describes a queryable module field
+type field struct {
+	kind fieldKind
+	text func(m *model.Module) []string  // kindText, and text matches on kindLevel
+	date func(m *model.Module) time.Time // kindDate
+}
+
+// fields maps field names and their aliases to definitions
+var fields = map[string]field{
+	"vendor":      {kind: kindText, text: func(m *model.Module) []string { return []string{m.VendorName} }},
+	"name":        {kind: kindText, text: func(m *model.Module) []string { return []string{m.ModuleName} }},
+	"type":        {kind: kindText, text: func(m *model.Module) []string { return []string{m.ModuleType} }},
+	"standard":    {kind: kindText, text: func(m *model.Module) []string { return []string{m.Standard} }},
+	"embodiment":  {kind: kindText, text: func(m *model.Module) []string { return []string{m.Embodiment} }},
+	"lab":         {kind: kindText, text: func(m *model.Module) []string { return []string{m.Lab} }},
+	"caveat":      {kind: kindText, text: func(m *model.Module) []string { return []string{m.Caveat} }},
+	"description": {kind: kindText, text: func(m *model.Module) []string { return []string{m.Description} }},
+	"stage":       {kind: kindText, text: func(m *model.Module) []string { return []string{string(m.Stage)} }},
+	"algo":        {kind: kindAlgo, text: algorithms},
+	"cavp":        {kind: kindCAVP},
+	"status":      {kind: kindStatus},
+	"level":       {kind: kindLevel, text: func(m *model.Module) []string { return []string{m.OverallLevel.Raw, m.OverallLevel.String()} }},
+	"cert":        {kind: kindCert},
+	"sunset":      {kind: kindDate, date: func(m *model.Module) time.Time { return m.SunsetDate }},
+	"validated":   {kind: kindDate, date: func(m *model.Module) time.Time { return m.ValidationDate }},
+}
+
+// aliases maps alternative field names to their canonical name
+var aliases = map[string]string{
+	"module":      "name",
+	"std":         "standard",
+	"desc":        "description",
+	"algorithm":   "algo",
+	"alg":         "algo",
+	"certificate": "cert",
+	"validation":  "validated",
+	"date":        "validated",
+}
+
+// Fields returns the canonical field names, sorted
+func Fields() []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func algorithms(m *model.Module) []string {
+	return append(append([]string(nil), m.Algorithms...), m.AlgorithmsDetailed...)
+}
+
+// compileTerm turns a term token into a matcher, validating its value
+func compileTerm(tok token) (node, error) {
+	if tok.field == "" {
+		return freeText(tok.value), nil
+	}
+
+	name := tok.field
+	if canonical, ok := aliases[name]; ok {
+		name = canonical
+	}
+	f, ok := fields[name]
+	if !ok {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("unknown field %q (try %s)", tok.field, strings.Join(Fields(), ", "))}
+	}
+	if tok.value == "" {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("missing value after %s%s", tok.field, tok.op)}
+	}
+
+	var (
+		n   node
+		err error
+	)
+	switch f.kind {
+	case kindText:
+		n, err = compileText(f.text, tok.op, tok.value)
+	case kindStatus:
+		n, err = compileStatus(tok.op, tok.value)
+	case kindLevel:
+		n, err = compileLevel(f.text, tok.op, tok.value)
+	case kindCert:
+		n, err = compileCert(tok.op, tok.value)
+	case kindDate:
+		n, err = compileDate(f.date, tok.op, tok.value)
+	case kindAlgo:
+		n, err = compileAlgo(f.text, tok.op, tok.value)
+	case kindCAVP:
+		n, err = compileCAVP(tok.op, tok.value)
+	}
+	if err != nil {
+		return nil, &Error{Pos: tok.pos, Msg: fmt.Sprintf("%s%s%s: %v", tok.field, tok.op, tok.value, err)}
+	}
+	return n, nil
+}
+
+// freeText matches the fields the plain list filter searches
+func freeText(value string) node {
+	value = strings.ToLower(value)
+	return matchFunc(func(m *model.Module) bool {
+		return strings.Contains(strings.ToLower(model.ModuleItem{Module: *m}.FilterValue()), value)
+	})
+}
+
+// compileText handles ":" (contains), "=" (equals) and "!=" (not equals),
+// all case-insensitive. Multi-valued fields match if any value does.
+func compileText(get func(m *model.Module) []string, op, value string) (node, error) {
+	value = strings.ToLower(value)
+	var test func(s string) bool
+	switch op {
+	case ":":
+		test = func(s string) bool { return strings.Contains(strings.ToLower(s), value) }
+	case "=", "!=":
+		test = func(s string) bool { return strings.EqualFold(s, value) }
+	default:
+		return nil, fmt.Errorf("operator %s is not supported for text", op)
+	}
+
+	n := matchFunc(func(m *model.Module) bool {
+		for _, s := range get(m) {
+			if test(s) {
+				return true
+			}
+		}
+		return false
+	})
+	if op == "!=" {
+		return notNode{n}, nil
+	}
+	return n, nil
+}
+
+// compileAlgo matches algorithms by normalized name, so algo:SHA-256 finds
+// SHA2-256 and algo:ML-KEM finds Kyber, as well as by text. A value more
+// specific than its normalized name, such as AES-GCM, only matches as text.
+func compileAlgo(text func(m *model.Module) []string, op, value string) (node, error) {
+	positive := op
+	if op == "!=" {
+		positive = "="
+	}
+	textNode, err := compileText(text, positive, value)
+	if err != nil {
+		return nil, err
+	}
+
+	var n node = textNode
+	name := model.NormalizeAlgorithm(value)
+	squashed, squashedName := squash(value), squash(name)
+	if squashed == squashedName || !strings.Contains(squashed, squashedName) {
+		n = matchFunc(func(m *model.Module) bool {
+			for _, algo := range m.AlgorithmNames() {
+				if algo == name {
+					return true
+				}
+			}
+			return textNode.match(m)
+		})
+	}
+
+	if op == "!=" {
+		return notNode{n}, nil
+	}
+	return n, nil
+}
+
+// squash uppercases s and drops separators
+func squash(s string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToUpper(s))
+}
+
+// compileCAVP matches CAVP certificate references such as A1234 exactly
+func compileCAVP(op, value string) (node, error) {
+	if op != ":" && op != "=" && op != "!=" {
+		return nil, fmt.Errorf("operator %s is not supported for cavp", op)
+	}
+	n := matchFunc(func(m *model.Module) bool {
+		for _, cert := range m.CAVPCerts() {
+			if strings.EqualFold(cert, value) {
+				return true
+			}
+		}
+		return false
+	})
+	if op == "!=" {
+		return notNode{n}, nil
+	}
+	return n, nil
+}
+
+// compileStatus matches active, historical or in-process, or any prefix
+func compileStatus(op, value string) (node, error) {
+	if op != ":" && op != "=" && op != "!=" {
+		return nil, fmt.Errorf("operator %s is not supported for status", op)
+	}
+
+	want := normalizeStatus(value)
+	var statuses []model.ModuleStatus
+	for _, s := range []model.ModuleStatus{model.StatusActive, model.StatusHistorical, model.StatusInProcess} {
+		if strings.HasPrefix(normalizeStatus(s.String()), want) {
+			statuses = append(statuses, s)
+		}
+	}
+	if len(statuses) != 1 {
+		return nil, fmt.Errorf("want active, historical or in-process")
+	}
+
+	status := statuses[0]
+	n := matchFunc(func(m *model.Module) bool { return m.Status == status })
+	if op == "!=" {
+		return notNode{n}, nil
+	}
+	return n, nil
+}
+
+func normalizeStatus(s string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(s))
+}
+
+// compileLevel compares numeric levels, or matches levels published as text
+// with ":" (e.g. level:tested)
+func compileLevel(text func(m *model.Module) []string, op, value string) (node, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		if op != ":" {
+			return nil, fmt.Errorf("want a level from 1 to 4")
+		}
+		return compileText(text, op, value)
+	}
+	if n < 1 || n > 4 {
+		return nil, fmt.Errorf("want a level from 1 to 4")
+	}
+
+	cmp := compareInts(op)
+	return matchFunc(func(m *model.Module) bool {
+		// Modules without a numeric level never satisfy a comparison
+		return m.OverallLevel.Level > 0 && cmp(m.OverallLevel.Level, n)
+	}), nil
+}
+
+// compileCert matches certificate numbers exactly, or compares them
+func compileCert(op, value string) (node, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("want a certificate number")
+	}
+
+	cmp := compareInts(op)
+	return matchFunc(func(m *model.Module) bool {
+		cert, err := strconv.Atoi(m.CertificateNumber)
+		return err == nil && cmp(cert, n)
+	}), nil
+}
+
+// compareInts returns the comparison for op; ":" means equals
+func compareInts(op string) func(a, b int) bool {
+	switch op {
+	case "<":
+		return func(a, b int) bool { return a < b }
+	case "<=":
+		return func(a, b int) bool { return a <= b }
+	case ">":
+		return func(a, b int) bool { return a > b }
+	case ">=":
+		return func(a, b int) bool { return a >= b }
+	case "!=":
+		return func(a, b int) bool { return a != b }
+	default:
+		return func(a, b int) bool { return a == b }
+	}
+}
+
+// compileDate compares dates against a day (2027-01-01), month (2027-01)
+// or year (2027). ":" and "=" match anywhere within that period.
+func compileDate(get func(m *model.Module) time.Time, op, value string) (node, error) {
+	start, end, err := parsePeriod(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var test func(t time.Time) bool
+	switch op {
+	case "<":
+		test = func(t time.Time) bool { return t.Before(start) }
+	case "<=":
+		test = func(t time.Time) bool { return t.Before(end) }
+	case ">":
+		test = func(t time.Time) bool { return !t.Before(end) }
+	case ">=":
+		test = func(t time.Time) bool { return !t.Before(start) }
+	case "!=":
+		test = func(t time.Time) bool { return t.Before(start) || !t.Before(end) }
+	default:
+		test = func(t time.Time) bool { return !t.Before(start) && t.Before(end) }
+	}
+	return matchFunc(func(m *model.Module) bool {
+		// Modules without the date never match
+		t := get(m)
+		return !t.IsZero() && test(t)
+	}), nil
+}
+
+// parsePeriod parses a date into the half-open period [start, end) it covers
+func parsePeriod(value string) (start, end time.Time, err error) {
+	for _, p := range []struct {
+		layout string
+		next   func(t time.Time) time.Time
+	}{
+		{"2006-01-02", func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }},
+		{"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+		{"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+	} {
+		if t, err := time.Parse(p.layout, value); err == nil {
+			return t, p.next(t), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("want a date like 2027-01-31, 2027-01 or 2027")
+}
@@ -0,0 +1,147 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenKind identifies a lexical token
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+	tokTerm
+)
+
+// token is a lexed token. Terms carry their field, operator and value;
+// free text terms have no field.
+type token struct {
+	kind  tokenKind
+	pos   int // Byte offset in the query
+	field string
+	op    string
+	value string
+}
+
+// operators in the order they are tried, so "<=" wins over "<"
+var operators = []string{"<=", ">=", "!=", ":", "=", "<", ">"}
+
+// lex splits a query into tokens
+func lex(s string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, pos: i})
+			i++
+		case c == '-' && i+1 < len(s) && !isSpace(s[i+1]):
+			tokens = append(tokens, token{kind: tokNot, pos: i})
+			i++
+		case c == '"':
+			value, next, err := lexQuoted(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokTerm, pos: i, value: value})
+			i = next
+		default:
+			tok, next, err := lexWord(s, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, tok)
+			i = next
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+// lexWord reads a bare word starting at i, which may be a keyword, free
+// text, or a field term such as level>=2 or standard:"FIPS 140-3"
+func lexWord(s string, i int) (token, int, error) {
+	start := i
+	for i < len(s) && !isSpace(s[i]) && s[i] != '(' && s[i] != ')' && s[i] != '"' {
+		i++
+	}
+	word := s[start:i]
+
+	switch word {
+	case "AND":
+		return token{kind: tokAnd, pos: start}, i, nil
+	case "OR":
+		return token{kind: tokOr, pos: start}, i, nil
+	case "NOT":
+		return token{kind: tokNot, pos: start}, i, nil
+	}
+
+	tok := token{kind: tokTerm, pos: start, value: word}
+	field, op, value, ok := splitTerm(word)
+	if !ok {
+		return tok, i, nil
+	}
+	tok.field, tok.op, tok.value = strings.ToLower(field), op, value
+
+	// A quoted value may follow the operator directly
+	if value == "" && i < len(s) && s[i] == '"' {
+		quoted, next, err := lexQuoted(s, i)
+		if err != nil {
+			return token{}, 0, err
+		}
+		tok.value = quoted
+		i = next
+	}
+	return tok, i, nil
+}
+
+// splitTerm splits "field<op>value". ok is false for free text.
+func splitTerm(word string) (field, op, value string, ok bool) {
+	idx := strings.IndexAny(word, ":<>=!")
+	if idx <= 0 {
+		return "", "", "", false
+	}
+	for _, r := range word[:idx] {
+		if !unicode.IsLetter(r) {
+			return "", "", "", false
+		}
+	}
+	for _, candidate := range operators {
+		if strings.HasPrefix(word[idx:], candidate) {
+			return word[:idx], candidate, word[idx+len(candidate):], true
+		}
+	}
+	return "", "", "", false
+}
+
+// lexQuoted reads a double-quoted phrase starting at i and returns its
+// contents and the offset after the closing quote. \" escapes a quote.
+func lexQuoted(s string, i int) (string, int, error) {
+	var b strings.Builder
+	for j := i + 1; j < len(s); j++ {
+		switch {
+		case s[j] == '\\' && j+1 < len(s) && s[j+1] == '"':
+			b.WriteByte('"')
+			j++
+		case s[j] == '"':
+			return b.String(), j + 1, nil
+		default:
+			b.WriteByte(s[j])
+		}
+	}
+	return "", 0, &Error{Pos: i, Msg: "unterminated quote"}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
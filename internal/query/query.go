@@ -0,0 +1,225 @@
+// Package query implements the module filter language used by the TUI and
+// the CLI. A query is a list of terms that must all match:
+//
+//	vendor:openssl status:active level>=2 algo:AES-GCM standard:"FIPS 140-3" sunset<2027-01-01
+//
+// Terms without a field match the certificate number, module name, vendor,
+// level and review stage. Terms combine with AND (implied), OR and NOT
+// (or a leading "-"), and parentheses group them. Quoted phrases may contain
+// spaces.
+package query
+
+import (
+	"fmt"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// Error is a syntax or value error in a query
+type Error struct {
+	Pos int // Byte offset of the problem in the query
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (at column %d)", e.Msg, e.Pos+1)
+}
+
+// Query is a parsed query
+type Query struct {
+	text string
+	root node // nil for an empty query, which matches everything
+}
+
+// Parse parses a query
+func Parse(text string) (*Query, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	q := &Query{text: text}
+	if p.peek().kind == tokEOF {
+		return q, nil
+	}
+	if q.root, err = p.parseOr(); err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected )"}
+	}
+	return q, nil
+}
+
+// MustParse is like Parse but panics on error
+func MustParse(text string) *Query {
+	q, err := Parse(text)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// String returns the query text
+func (q *Query) String() string {
+	return q.text
+}
+
+// Match reports whether the module satisfies the query
+func (q *Query) Match(m *model.Module) bool {
+	if q.root == nil {
+		return true
+	}
+	return q.root.match(m)
+}
+
+// Filter returns the modules that satisfy the query
+func (q *Query) Filter(modules []model.Module) []model.Module {
+	var matched []model.Module
+	for i := range modules {
+		if q.Match(&modules[i]) {
+			matched = append(matched, modules[i])
+		}
+	}
+	return matched
+}
+
+// node is a node of a parsed query
+type node interface {
+	match(m *model.Module) bool
+}
+
+type andNode []node
+
+func (n andNode) match(m *model.Module) bool {
+	for _, child := range n {
+		if !child.match(m) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode []node
+
+func (n orNode) match(m *model.Module) bool {
+	for _, child := range n {
+		if child.match(m) {
+			return true
+		}
+	}
+	return false
+}
+
+type notNode struct {
+	node
+}
+
+func (n notNode) match(m *model.Module) bool {
+	return !n.node.match(m)
+}
+
+// matchFunc is a compiled term
+type matchFunc func(m *model.Module) bool
+
+func (f matchFunc) match(m *model.Module) bool {
+	return f(m)
+}
+
+// parser is a recursive descent parser over lexed tokens:
+//
+//	or   = and { "OR" and }
+//	and  = not { ["AND"] not }
+//	not  = ( "NOT" | "-" ) not | "(" or ")" | term
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	nodes := orNode{left}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, right)
+	}
+	if len(nodes) == 1 {
+		return left, nil
+	}
+	return nodes, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	nodes := andNode{left}
+	for {
+		switch p.peek().kind {
+		case tokAnd:
+			p.next()
+		case tokTerm, tokNot, tokLParen:
+			// Adjacent terms are implicitly ANDed
+		default:
+			if len(nodes) == 1 {
+				return left, nil
+			}
+			return nodes, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, right)
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNot:
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case tokLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &Error{Pos: tok.pos, Msg: "missing )"}
+		}
+		return inner, nil
+	case tokTerm:
+		return compileTerm(tok)
+	case tokEOF:
+		return nil, &Error{Pos: tok.pos, Msg: "query ends too early"}
+	case tokRParen:
+		return nil, &Error{Pos: tok.pos, Msg: "unexpected )"}
+	default:
+		return nil, &Error{Pos: tok.pos, Msg: "expected a search term"}
+	}
+}
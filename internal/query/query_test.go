@@ -0,0 +1,196 @@
+package query
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+var testModules = []model.Module{
+	{
+		CertificateNumber:  "4282",
+		VendorName:         "OpenSSL Software Foundation",
+		ModuleName:         "OpenSSL FIPS Provider",
+		ModuleType:         "Software",
+		Status:             model.StatusActive,
+		Standard:           "FIPS 140-3",
+		OverallLevel:       model.NewSecurityLevel(1),
+		ValidationDate:     time.Date(2022, 10, 25, 0, 0, 0, 0, time.UTC),
+		SunsetDate:         time.Date(2027, 10, 24, 0, 0, 0, 0, time.UTC),
+		Lab:                "ACUMEN SECURITY, LLC",
+		Algorithms:         []string{"AES", "SHA-3"},
+		AlgorithmsDetailed: []string{"AES-GCM (A1234)", "SHA3-256 (A1234)", "SHA-256 (A1235)"},
+	},
+	{
+		CertificateNumber: "3900",
+		VendorName:        "Acme HSM Corp",
+		ModuleName:        "Acme Hardware Security Module",
+		ModuleType:        "Hardware",
+		Status:            model.StatusActive,
+		Standard:          "FIPS 140-2",
+		OverallLevel:      model.NewSecurityLevel(3),
+		SunsetDate:        time.Date(2026, 9, 21, 0, 0, 0, 0, time.UTC),
+		Caveat:            "When operated in FIPS mode",
+		Algorithms:        []string{"AES", "RSA", "CRYSTALS-Kyber"},
+	},
+	{
+		CertificateNumber: "1051",
+		VendorName:        "OpenSSL Software Foundation",
+		ModuleName:        "OpenSSL FIPS Object Module",
+		ModuleType:        "Software",
+		Status:            model.StatusHistorical,
+		Standard:          "FIPS 140-2",
+		OverallLevel:      model.ParseSecurityLevel("Tested Configuration(s)"),
+	},
+	{
+		VendorName: "PQ Labs",
+		ModuleName: "Quantum Safe Module",
+		Status:     model.StatusInProcess,
+		Standard:   "FIPS 140-3",
+		Stage:      model.StageInReview,
+	},
+}
+
+// certs returns the certificate numbers (or names, if none) of the modules
+// the query matches
+func certs(t *testing.T, text string) string {
+	t.Helper()
+	q, err := Parse(text)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", text, err)
+	}
+	var matched []string
+	for _, m := range q.Filter(testModules) {
+		if m.CertificateNumber != "" {
+			matched = append(matched, m.CertificateNumber)
+		} else {
+			matched = append(matched, m.ModuleName)
+		}
+	}
+	return strings.Join(matched, ",")
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "4282,3900,1051,Quantum Safe Module"},
+		{"openssl", "4282,1051"},
+		{"OPENSSL provider", "4282"},
+		{"level", ""}, // Free text searches only cert, name and vendor
+		{"review", ""},
+		{`"FIPS Object"`, "1051"},
+		{"vendor:openssl status:active", "4282"},
+		{"vendor=acme", ""},
+		{`vendor="Acme HSM Corp"`, "3900"},
+		{"status:hist", "1051"},
+		{"status:in-process", "Quantum Safe Module"},
+		{"status!=active", "1051,Quantum Safe Module"},
+		{"level>=2", "3900"},
+		{"level<3", "4282"},
+		{"level:1", "4282"},
+		{"level!=1", "3900"},
+		{"level:tested", "1051"},
+		{"algo:AES-GCM", "4282"},
+		{"algorithm:rsa", "3900"},
+		{"algo:sha2-256", "4282"},
+		{"algo:ML-KEM", "3900"},
+		{"algo:aes", "4282,3900"},
+		{"algo!=rsa", "4282,1051,Quantum Safe Module"},
+		{"cavp:a1235", "4282"},
+		{"cavp:A123", ""},
+		{`standard:"FIPS 140-3"`, "4282,Quantum Safe Module"},
+		{"std:140-2", "3900,1051"},
+		{"sunset<2027-01-01", "3900"},
+		{"sunset>=2027", "4282"},
+		{"sunset:2026-09", "3900"},
+		{"sunset<=2026-09-21", "3900"},
+		{"sunset>2026-09-21", "4282"},
+		{"validated:2022", "4282"},
+		{"cert>=4000", "4282"},
+		{"cert:1051", "1051"},
+		{"stage:review", "Quantum Safe Module"},
+		{"caveat:fips", "3900"},
+		{"lab:acumen", "4282"},
+		{"type:hardware OR status:historical", "3900,1051"},
+		{"vendor:openssl AND NOT status:historical", "4282"},
+		{"vendor:openssl -status:historical", "4282"},
+		{"-(vendor:openssl OR vendor:acme)", "Quantum Safe Module"},
+		{"(type:hardware OR type:software) level>=1", "4282,3900"},
+		{"status:active OR status:historical status:active", "4282,3900"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			if got := certs(t, tt.query); got != tt.want {
+				t.Errorf("matched %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		query   string
+		wantMsg string
+		wantPos int
+	}{
+		{`vendor:"openssl`, "unterminated quote", 7},
+		{"color:red", `unknown field "color"`, 0},
+		{"vendor:", "missing value after vendor:", 0},
+		{"level>=five", "want a level from 1 to 4", 0},
+		{"level:7", "want a level from 1 to 4", 0},
+		{"status:pending", "want active, historical or in-process", 0},
+		{"sunset<soon", "want a date", 0},
+		{"vendor<x", "operator < is not supported for text", 0},
+		{"cert:abc", "want a certificate number", 0},
+		{"(vendor:x", "missing )", 0},
+		{"vendor:x)", "unexpected )", 8},
+		{"vendor:x OR", "query ends too early", 11},
+		{"NOT", "query ends too early", 3},
+		{"a OR OR b", "expected a search term", 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var qerr *Error
+			if !errors.As(err, &qerr) {
+				t.Fatalf("Parse(%q) error = %v, want *Error", tt.query, err)
+			}
+			if !strings.Contains(qerr.Msg, tt.wantMsg) {
+				t.Errorf("Msg = %q, want it to contain %q", qerr.Msg, tt.wantMsg)
+			}
+			if qerr.Pos != tt.wantPos {
+				t.Errorf("Pos = %d, want %d", qerr.Pos, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	err := &Error{Pos: 4, Msg: "unterminated quote"}
+	if got := err.Error(); got != "unterminated quote (at column 5)" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestQuery_String(t *testing.T) {
+	if got := MustParse("level>=2").String(); got != "level>=2" {
+		t.Errorf("String() = %q, want level>=2", got)
+	}
+}
+
+func TestLex_QuotedEscape(t *testing.T) {
+	tokens, err := lex(`name:"a \"quoted\" name"`)
+	if err != nil {
+		t.Fatalf("lex() error = %v", err)
+	}
+	if tokens[0].value != `a "quoted" name` {
+		t.Errorf("value = %q", tokens[0].value)
+	}
+}
@@ -0,0 +1,189 @@
+// Package report renders certificate reports for system security plan
+// appendices and similar documents. Reports are Go templates over
+// model.Module data; the built-in Markdown and HTML templates can be
+// replaced with a user-supplied template.
+package report
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Format is the kind of document a report renders
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+)
+
+// ParseFormat returns the format called name, ignoring case. "md" is
+// accepted for Markdown.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	case "html":
+		return FormatHTML, nil
+	default:
+		return "", fmt.Errorf("unknown report format %q (want markdown or html)", name)
+	}
+}
+
+// Data is what a report template is executed with
+type Data struct {
+	Title     string
+	Generated time.Time      // When the report was rendered
+	DataAsOf  string         // generated_at of the CMVP data, if known
+	Modules   []model.Module // In the order they were requested
+}
+
+// executor is satisfied by both text/template and html/template
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// Report is a parsed report template
+type Report struct {
+	tmpl executor
+}
+
+// New returns the built-in template for format
+func New(format Format) (*Report, error) {
+	name := "templates/report.md.tmpl"
+	if format == FormatHTML {
+		name = "templates/report.html.tmpl"
+	}
+	text, err := templates.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(format, name, string(text))
+}
+
+// Load reads a user template from path. It uses text/template syntax and
+// has the same functions as the built-in templates; HTML templates are
+// parsed with html/template so module data is escaped.
+func Load(format Format, path string) (*Report, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(format, path, string(text))
+}
+
+// Parse parses a report template named name
+func Parse(format Format, name, text string) (*Report, error) {
+	switch format {
+	case FormatMarkdown:
+		tmpl, err := template.New(name).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return &Report{tmpl: tmpl}, nil
+	case FormatHTML:
+		tmpl, err := htmltemplate.New(name).Funcs(funcs).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return &Report{tmpl: tmpl}, nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// Render writes the report for data to w
+func (r *Report) Render(w io.Writer, data Data) error {
+	return r.tmpl.Execute(w, data)
+}
+
+// funcs are available to every report template
+var funcs = map[string]any{
+	"date":        model.FormatDate,
+	"join":        strings.Join,
+	"keySizes":    keySizes,
+	"approved":    approvedAlgorithms,
+	"nonApproved": nonApprovedAlgorithms,
+	"daysToSunset": func(m model.Module, now time.Time) string {
+		days, ok := m.DaysUntilSunset(now)
+		if !ok {
+			return ""
+		}
+		return strconv.Itoa(days)
+	},
+	"cell": markdownCell,
+	"default": func(fallback, s string) string {
+		if s == "" {
+			return fallback
+		}
+		return s
+	},
+}
+
+func keySizes(sizes []int) string {
+	s := make([]string, len(sizes))
+	for i, n := range sizes {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ", ")
+}
+
+func approvedAlgorithms(m model.Module) []model.AlgorithmEntry {
+	return filterAlgorithms(m, true)
+}
+
+func nonApprovedAlgorithms(m model.Module) []model.AlgorithmEntry {
+	return filterAlgorithms(m, false)
+}
+
+func filterAlgorithms(m model.Module, approved bool) []model.AlgorithmEntry {
+	var entries []model.AlgorithmEntry
+	for _, e := range m.AlgorithmEntries() {
+		if e.Approved == approved {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+var markdownCellReplacer = strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ", "\r", " ")
+
+// markdownCell escapes s for a Markdown table cell
+func markdownCell(s string) string {
+	return markdownCellReplacer.Replace(strings.TrimSpace(s))
+}
+
+// ReadCerts reads certificate numbers from r. Numbers are separated by
+// whitespace or commas and may be written as "#4282"; a "#" that is not
+// followed by a digit starts a comment that runs to the end of the line.
+func ReadCerts(r io.Reader) ([]string, error) {
+	var certs []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.FieldsFunc(sc.Text(), func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})
+		for _, field := range fields {
+			cert, isRef := strings.CutPrefix(field, "#")
+			if isRef && (cert == "" || !unicode.IsDigit(rune(cert[0]))) {
+				break
+			}
+			certs = append(certs, cert)
+		}
+	}
+	return certs, sc.Err()
+}
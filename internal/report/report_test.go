@@ -0,0 +1,146 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+var testData = Data{
+	Title:     "SSP Appendix: Cryptographic Modules",
+	Generated: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	DataAsOf:  "2026-02-28T06:00:00Z",
+	Modules: []model.Module{
+		{
+			CertificateNumber:  "4282",
+			CertificateURL:     "https://csrc.nist.gov/projects/cryptographic-module-validation-program/certificate/4282",
+			ModuleName:         "OpenSSL FIPS Provider",
+			VendorName:         "OpenSSL Software Foundation",
+			Status:             model.StatusActive,
+			Standard:           "FIPS 140-3",
+			OverallLevel:       model.NewSecurityLevel(1),
+			ValidationDate:     time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC),
+			SunsetDate:         time.Date(2029, 7, 11, 0, 0, 0, 0, time.UTC),
+			Caveat:             "When operated in approved mode | <with> care",
+			AlgorithmsDetailed: []string{"AES-GCM: (A4510) 128, 256", "MD5 (non-approved, allowed)"},
+		},
+		{
+			CertificateNumber: "1234",
+			ModuleName:        "Legacy Module",
+			VendorName:        "Acme",
+			Status:            model.StatusHistorical,
+			Algorithms:        []string{"AES", "SHS"},
+		},
+	},
+}
+
+func TestRender_Markdown(t *testing.T) {
+	r, err := New(FormatMarkdown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, testData); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"# SSP Appendix: Cryptographic Modules",
+		"Generated 2026-03-01 from NIST CMVP data as of 2026-02-28T06:00:00Z.",
+		"| [#4282](https://csrc.nist.gov/projects/cryptographic-module-validation-program/certificate/4282) | OpenSSL FIPS Provider |",
+		"## OpenSSL FIPS Provider (Certificate #4282)",
+		"| Overall level | Level 1 |",
+		"| Sunset date | 2029-07-11 |",
+		"| AES | GCM | 128, 256 | A4510 |",
+		"- MD5 (non-approved, allowed)",
+		"## Legacy Module (Certificate #1234)",
+		"| Standard | Not published |",
+		"**Caveat:** None",
+		"AES, SHS",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\n\n\n") {
+		t.Errorf("report has runs of blank lines:\n%s", out)
+	}
+}
+
+func TestRender_HTML(t *testing.T) {
+	r, err := New(FormatHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, testData); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>SSP Appendix: Cryptographic Modules</title>",
+		"<h2>OpenSSL FIPS Provider (Certificate #4282)</h2>",
+		"When operated in approved mode | &lt;with&gt; care",
+		"<td>AES</td><td>GCM</td><td>128, 256</td><td>A4510</td>",
+		"</html>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.tmpl")
+	text := `{{range .Modules}}{{.CertificateNumber}}: {{.OverallLevel}}, sunset in {{daysToSunset . $.Generated}} days{{"\n"}}{{end}}`
+	if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(FormatMarkdown, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := r.Render(&buf, testData); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.HasPrefix(got, "4282: Level 1, sunset in 1228 days\n") {
+		t.Errorf("custom template output = %q", got)
+	}
+
+	if _, err := Parse(FormatMarkdown, "bad", "{{.Modules"); err == nil {
+		t.Error("expected a parse error")
+	}
+}
+
+func TestReadCerts(t *testing.T) {
+	input := `# Modules in the boundary
+4282, 4718
+#3456  # vendor HSM
+	#
+9999`
+	certs, err := ReadCerts(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(certs, ","); got != "4282,4718,3456,9999" {
+		t.Errorf("certs = %s", got)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, want := range map[string]Format{"md": FormatMarkdown, "Markdown": FormatMarkdown, "HTML": FormatHTML} {
+		if got, err := ParseFormat(name); err != nil || got != want {
+			t.Errorf("ParseFormat(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := ParseFormat("pdf"); err == nil {
+		t.Error("expected an error for pdf")
+	}
+}
@@ -0,0 +1,273 @@
+// Package snapshot saves the combined CMVP dataset at a point in time and
+// reports what changed between two snapshots: certificates added and
+// removed, status transitions, caveats, sunset dates and algorithms
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/fsutil"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// Snapshot is the combined dataset as published at GeneratedAt
+type Snapshot struct {
+	GeneratedAt string    `json:"generated_at"` // MetadataJSON.GeneratedAt of the data
+	SavedAt     time.Time `json:"saved_at"`
+	Modules     []Record  `json:"modules"`
+}
+
+// Record is the state of a module that changes are reported in
+type Record struct {
+	CertificateNumber string   `json:"certificate_number,omitempty"`
+	VendorName        string   `json:"vendor_name"`
+	ModuleName        string   `json:"module_name"`
+	Status            string   `json:"status"`
+	Stage             string   `json:"stage,omitempty"`
+	Standard          string   `json:"standard,omitempty"`
+	OverallLevel      string   `json:"overall_level,omitempty"`
+	ValidationDate    string   `json:"validation_date,omitempty"`
+	SunsetDate        string   `json:"sunset_date,omitempty"`
+	Caveat            string   `json:"caveat,omitempty"`
+	Algorithms        []string `json:"algorithms,omitempty"` // Normalized, as model.Module.AlgorithmNames
+}
+
+// New captures modules, published at generatedAt, as a snapshot
+func New(modules []model.Module, generatedAt string, now time.Time) *Snapshot {
+	s := &Snapshot{GeneratedAt: generatedAt, SavedAt: now, Modules: make([]Record, len(modules))}
+	for i, m := range modules {
+		s.Modules[i] = Record{
+			CertificateNumber: m.CertificateNumber,
+			VendorName:        m.VendorName,
+			ModuleName:        m.ModuleName,
+			Status:            m.Status.String(),
+			Stage:             string(m.Stage),
+			Standard:          m.Standard,
+			OverallLevel:      m.OverallLevel.String(),
+			ValidationDate:    model.FormatDate(m.ValidationDate),
+			SunsetDate:        model.FormatDate(m.SunsetDate),
+			Caveat:            m.Caveat,
+			Algorithms:        m.AlgorithmNames(),
+		}
+	}
+	return s
+}
+
+// Label describes when the snapshot's data was published, falling back to
+// when it was saved
+func (s *Snapshot) Label() string {
+	if s.GeneratedAt != "" {
+		return s.GeneratedAt
+	}
+	return "saved " + s.SavedAt.Format(time.RFC3339)
+}
+
+// FileName returns a file name stamped with the snapshot's generated_at,
+// e.g. cmvp-snapshot-20260228T060000Z.json
+func (s *Snapshot) FileName() string {
+	stamp := s.SavedAt.UTC().Format("20060102T150405Z")
+	if t, err := time.Parse(time.RFC3339, s.GeneratedAt); err == nil {
+		stamp = t.UTC().Format("20060102T150405Z")
+	}
+	return "cmvp-snapshot-" + stamp + ".json"
+}
+
+// LastSeenPath returns the snapshot the TUI compares against, in dir
+func LastSeenPath(dir string) string {
+	return filepath.Join(dir, "last-seen-snapshot.json")
+}
+
+// Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// Save writes the snapshot to path, creating its directory
+func (s *Snapshot) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(path, data, 0o600)
+}
+
+// Diff is what changed between two snapshots. Only modules with a
+// certificate number are compared; in-process modules appear as added once
+// they are validated.
+type Diff struct {
+	From    string   `json:"from"` // Label of the older snapshot
+	To      string   `json:"to"`   // Label of the newer snapshot
+	Added   []Record `json:"added,omitempty"`
+	Removed []Record `json:"removed,omitempty"`
+	Changed []Change `json:"changed,omitempty"`
+}
+
+// Change is how one certificate changed
+type Change struct {
+	Module            Record      `json:"module"` // As in the newer snapshot
+	Status            *Transition `json:"status,omitempty"`
+	OverallLevel      *Transition `json:"overall_level,omitempty"`
+	Caveat            *Transition `json:"caveat,omitempty"`
+	SunsetDate        *Transition `json:"sunset_date,omitempty"`
+	AlgorithmsAdded   []string    `json:"algorithms_added,omitempty"`
+	AlgorithmsRemoved []string    `json:"algorithms_removed,omitempty"`
+}
+
+// Transition is a field's value in the older and newer snapshots
+type Transition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Compare reports what changed from older to newer. Results are ordered by
+// certificate number.
+func Compare(older, newer *Snapshot) Diff {
+	d := Diff{From: older.Label(), To: newer.Label()}
+	was := byCert(older.Modules)
+	now := byCert(newer.Modules)
+
+	for cert, r := range now {
+		prev, ok := was[cert]
+		if !ok {
+			d.Added = append(d.Added, r)
+			continue
+		}
+		if c, changed := compareRecords(prev, r); changed {
+			d.Changed = append(d.Changed, c)
+		}
+	}
+	for cert, r := range was {
+		if _, ok := now[cert]; !ok {
+			d.Removed = append(d.Removed, r)
+		}
+	}
+
+	sort.Slice(d.Added, func(i, j int) bool { return certLess(d.Added[i], d.Added[j]) })
+	sort.Slice(d.Removed, func(i, j int) bool { return certLess(d.Removed[i], d.Removed[j]) })
+	sort.Slice(d.Changed, func(i, j int) bool { return certLess(d.Changed[i].Module, d.Changed[j].Module) })
+	return d
+}
+
+// Empty reports whether nothing changed
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Len returns the number of certificates that were added, removed or changed
+func (d Diff) Len() int {
+	return len(d.Added) + len(d.Removed) + len(d.Changed)
+}
+
+// Descriptions describes each part of the change, e.g.
+// "Status Active → Historical" or "Overall Level 1 → Level 2"
+func (c Change) Descriptions() []string {
+	var lines []string
+	if c.Status != nil {
+		lines = append(lines, fmt.Sprintf("Status %s → %s", c.Status.From, c.Status.To))
+	}
+	if c.OverallLevel != nil {
+		lines = append(lines, fmt.Sprintf("Overall %s → %s", orNone(c.OverallLevel.From), orNone(c.OverallLevel.To)))
+	}
+	if c.Caveat != nil {
+		switch {
+		case c.Caveat.From == "":
+			lines = append(lines, "Caveat added: "+c.Caveat.To)
+		case c.Caveat.To == "":
+			lines = append(lines, "Caveat removed: "+c.Caveat.From)
+		default:
+			lines = append(lines, "Caveat changed: "+c.Caveat.To)
+		}
+	}
+	if c.SunsetDate != nil {
+		lines = append(lines, fmt.Sprintf("Sunset %s → %s", orNone(c.SunsetDate.From), orNone(c.SunsetDate.To)))
+	}
+	if len(c.AlgorithmsAdded) > 0 {
+		lines = append(lines, "Algorithms added: "+strings.Join(c.AlgorithmsAdded, ", "))
+	}
+	if len(c.AlgorithmsRemoved) > 0 {
+		lines = append(lines, "Algorithms removed: "+strings.Join(c.AlgorithmsRemoved, ", "))
+	}
+	return lines
+}
+
+func compareRecords(was, now Record) (Change, bool) {
+	c := Change{Module: now}
+	changed := false
+	transition := func(from, to string) *Transition {
+		if from == to {
+			return nil
+		}
+		changed = true
+		return &Transition{From: from, To: to}
+	}
+	c.Status = transition(was.Status, now.Status)
+	c.OverallLevel = transition(was.OverallLevel, now.OverallLevel)
+	c.Caveat = transition(was.Caveat, now.Caveat)
+	c.SunsetDate = transition(was.SunsetDate, now.SunsetDate)
+	c.AlgorithmsAdded = missingFrom(now.Algorithms, was.Algorithms)
+	c.AlgorithmsRemoved = missingFrom(was.Algorithms, now.Algorithms)
+	if len(c.AlgorithmsAdded) > 0 || len(c.AlgorithmsRemoved) > 0 {
+		changed = true
+	}
+	return c, changed
+}
+
+// missingFrom returns the names in names that are not in other
+func missingFrom(names, other []string) []string {
+	var missing []string
+	for _, n := range names {
+		if !slices.Contains(other, n) {
+			missing = append(missing, n)
+		}
+	}
+	return missing
+}
+
+func byCert(records []Record) map[string]Record {
+	m := make(map[string]Record, len(records))
+	for _, r := range records {
+		if r.CertificateNumber != "" {
+			m[r.CertificateNumber] = r
+		}
+	}
+	return m
+}
+
+// certLess orders certificate numbers numerically
+func certLess(a, b Record) bool {
+	na, errA := strconv.Atoi(a.CertificateNumber)
+	nb, errB := strconv.Atoi(b.CertificateNumber)
+	if errA == nil && errB == nil {
+		return na < nb
+	}
+	return a.CertificateNumber < b.CertificateNumber
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
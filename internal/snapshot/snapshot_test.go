@@ -0,0 +1,113 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+var savedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestCompare(t *testing.T) {
+	older := New([]model.Module{
+		{CertificateNumber: "10", ModuleName: "Retired", Status: model.StatusActive},
+		{CertificateNumber: "9", ModuleName: "Steady", Status: model.StatusActive, Algorithms: []string{"AES"}},
+		{CertificateNumber: "200", ModuleName: "Raised", Status: model.StatusActive, OverallLevel: model.NewSecurityLevel(2)},
+		{CertificateNumber: "100", ModuleName: "Moving", Status: model.StatusActive, OverallLevel: model.NewSecurityLevel(1),
+			SunsetDate: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC), Algorithms: []string{"AES", "Triple-DES"}},
+		{ModuleName: "Pending", Status: model.StatusInProcess},
+	}, "2026-01-01T00:00:00Z", savedAt)
+	newer := New([]model.Module{
+		{CertificateNumber: "9", ModuleName: "Steady", Status: model.StatusActive, Algorithms: []string{"AES"}},
+		{CertificateNumber: "200", ModuleName: "Raised", Status: model.StatusActive, OverallLevel: model.NewSecurityLevel(3)},
+		{CertificateNumber: "100", ModuleName: "Moving", Status: model.StatusHistorical, OverallLevel: model.NewSecurityLevel(2),
+			Caveat: "No assurance of the minimum strength", SunsetDate: time.Date(2027, 9, 1, 0, 0, 0, 0, time.UTC), Algorithms: []string{"AES", "ML-KEM"}},
+		{CertificateNumber: "5001", ModuleName: "Pending", Status: model.StatusActive},
+	}, "2026-02-01T00:00:00Z", savedAt)
+
+	d := Compare(older, newer)
+	if d.From != "2026-01-01T00:00:00Z" || d.To != "2026-02-01T00:00:00Z" {
+		t.Errorf("labels = %q → %q", d.From, d.To)
+	}
+	if len(d.Added) != 1 || d.Added[0].CertificateNumber != "5001" {
+		t.Errorf("added = %+v, want 5001", d.Added)
+	}
+	if len(d.Removed) != 1 || d.Removed[0].CertificateNumber != "10" {
+		t.Errorf("removed = %+v, want 10", d.Removed)
+	}
+	if len(d.Changed) != 2 || d.Changed[0].Module.CertificateNumber != "100" || d.Changed[1].Module.CertificateNumber != "200" {
+		t.Fatalf("changed = %+v, want 100 and 200", d.Changed)
+	}
+
+	want := []string{
+		"Status Active → Historical",
+		"Overall Level 1 → Level 2",
+		"Caveat added: No assurance of the minimum strength",
+		"Sunset 2026-09-01 → 2027-09-01",
+		"Algorithms added: ML-KEM",
+		"Algorithms removed: TDES",
+	}
+	if got := d.Changed[0].Descriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("descriptions = %q, want %q", got, want)
+	}
+	// A level change alone is reported
+	if got := d.Changed[1].Descriptions(); !reflect.DeepEqual(got, []string{"Overall Level 2 → Level 3"}) {
+		t.Errorf("descriptions = %q, want only the level change", got)
+	}
+	if d.Len() != 4 || d.Empty() {
+		t.Errorf("Len = %d, Empty = %v", d.Len(), d.Empty())
+	}
+	if !Compare(newer, newer).Empty() {
+		t.Error("a snapshot should not differ from itself")
+	}
+}
+
+func TestCompare_Order(t *testing.T) {
+	newer := New([]model.Module{{CertificateNumber: "100"}, {CertificateNumber: "20"}, {CertificateNumber: "3"}}, "", savedAt)
+	d := Compare(&Snapshot{}, newer)
+	var certs []string
+	for _, r := range d.Added {
+		certs = append(certs, r.CertificateNumber)
+	}
+	if got := strings.Join(certs, ","); got != "3,20,100" {
+		t.Errorf("added order = %s, want numeric", got)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	s := New([]model.Module{{CertificateNumber: "4282", ModuleName: "Provider", Status: model.StatusActive,
+		OverallLevel: model.NewSecurityLevel(1), Algorithms: []string{"AES"}}}, "2026-02-28T06:00:00Z", savedAt)
+	path := filepath.Join(t.TempDir(), "nested", s.FileName())
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(path) != "cmvp-snapshot-20260228T060000Z.json" {
+		t.Errorf("file name = %s", filepath.Base(path))
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, s) {
+		t.Errorf("loaded %+v, want %+v", loaded, s)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing snapshot")
+	}
+}
+
+func TestLabel(t *testing.T) {
+	s := &Snapshot{SavedAt: savedAt}
+	if got := s.Label(); got != "saved 2026-03-01T12:00:00Z" {
+		t.Errorf("Label() = %q", got)
+	}
+	if got := s.FileName(); got != "cmvp-snapshot-20260301T120000Z.json" {
+		t.Errorf("FileName() = %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/index"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// algorithmItem is an entry in the algorithms view
+type algorithmItem struct {
+	index.AlgorithmCount
+}
+
+// Title returns the normalized algorithm name
+func (a algorithmItem) Title() string {
+	return a.Name
+}
+
+// Description returns how many modules implement the algorithm
+func (a algorithmItem) Description() string {
+	if a.Modules == 1 {
+		return "1 module"
+	}
+	return fmt.Sprintf("%d modules", a.Modules)
+}
+
+// FilterValue returns the string used for filtering
+func (a algorithmItem) FilterValue() string {
+	return a.Name
+}
+
+// openAlgorithms indexes the loaded modules by algorithm and shows every
+// algorithm with the number of modules implementing it
+func (m Model) openAlgorithms() Model {
+	modules := make([]model.Module, 0, len(m.allModules))
+	for _, item := range m.allModules {
+		if mi, ok := item.(model.ModuleItem); ok {
+			modules = append(modules, mi.Module)
+		}
+	}
+
+	counts := index.NewAlgorithmIndex(modules).Algorithms()
+	items := make([]list.Item, len(counts))
+	for i, c := range counts {
+		items[i] = algorithmItem{c}
+	}
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Foreground(PrimaryColor).BorderForeground(PrimaryColor)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.BorderForeground(PrimaryColor)
+
+	m.algoList = list.New(items, delegate, m.width-4, m.height-6)
+	m.algoList.Title = "Algorithms"
+	m.algoList.Styles.Title = TitleStyle
+	m.algoList.SetShowStatusBar(true)
+	m.algoList.SetStatusBarItemName("algorithm", "algorithms")
+	m.algoList.FilterInput.Prompt = "Filter: "
+	m.algoList.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+	m.view = ViewAlgorithms
+	return m
+}
+
+// selectAlgorithm returns to the module list, filtered to the modules that
+// implement the selected algorithm
+func (m Model) selectAlgorithm() Model {
+	item, ok := m.algoList.SelectedItem().(algorithmItem)
+	if !ok {
+		return m
+	}
+	m.view = ViewList
+	m.list.SetFilterText(fmt.Sprintf("algo:%q", item.Name))
+	return m
+}
+
+func (m Model) renderAlgorithmsView() string {
+	help := HelpStyle.Render("enter: list modules with this algorithm • /: filter • esc: back")
+	return AppStyle.Render(m.algoList.View() + "\n" + help)
+}
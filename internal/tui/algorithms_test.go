@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// loadedModel returns a model that has finished loading items
+func TestModel_AlgorithmsView(t *testing.T) {
+	m := loadedModel(t, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "AES Module", Algorithms: []string{"AES"}}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "2", ModuleName: "Both Module", Algorithms: []string{"AES", "CRYSTALS-Kyber"}}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "3", ModuleName: "RSA Module", Algorithms: []string{"RSA"}}},
+	})
+
+	newModel, _ := m.Update(keyMsg("a"))
+	m = newModel.(Model)
+	if m.view != ViewAlgorithms {
+		t.Fatalf("view = %v, want ViewAlgorithms", m.view)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Algorithms", "AES", "2 modules", "ML-KEM", "1 module"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("algorithms view missing %q", want)
+		}
+	}
+
+	// AES is implemented most widely, so it is listed and selected first
+	newModel, _ = m.Update(keyMsg("enter"))
+	m = newModel.(Model)
+	if m.view != ViewList {
+		t.Fatalf("view = %v, want ViewList after selecting an algorithm", m.view)
+	}
+	if got := m.list.FilterValue(); got != `algo:"AES"` {
+		t.Errorf("filter = %q, want algo:\"AES\"", got)
+	}
+	if visible := m.list.VisibleItems(); len(visible) != 2 {
+		t.Errorf("expected the 2 AES modules, got %d", len(visible))
+	}
+}
+
+func TestModel_AlgorithmsView_Back(t *testing.T) {
+	m := loadedModel(t, []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "AES Module", Algorithms: []string{"AES"}}},
+	})
+
+	for _, k := range []string{"esc", "q"} {
+		newModel, _ := m.Update(keyMsg("a"))
+		m = newModel.(Model)
+		newModel, _ = m.Update(keyMsg(k))
+		m = newModel.(Model)
+		if m.view != ViewList {
+			t.Errorf("%s: view = %v, want ViewList", k, m.view)
+		}
+	}
+}
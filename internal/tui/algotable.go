@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// algoSortColumn is the column the detailed algorithm table is sorted by
+type algoSortColumn int
+
+const (
+	algoSortListed algoSortColumn = iota // Order the certificate lists them in
+	algoSortFamily
+	algoSortMode
+	algoSortKeySize
+	algoSortCAVP
+	algoSortApproved
+	algoSortColumns // Number of sort columns
+)
+
+func (c algoSortColumn) String() string {
+	switch c {
+	case algoSortFamily:
+		return "algorithm"
+	case algoSortMode:
+		return "mode"
+	case algoSortKeySize:
+		return "key size"
+	case algoSortCAVP:
+		return "CAVP cert"
+	case algoSortApproved:
+		return "approval"
+	default:
+		return "listed order"
+	}
+}
+
+// sortAlgorithmEntries returns entries sorted by column, keeping the listed
+// order between equal entries
+func sortAlgorithmEntries(entries []model.AlgorithmEntry, column algoSortColumn, descending bool) []model.AlgorithmEntry {
+	sorted := append([]model.AlgorithmEntry(nil), entries...)
+	less := func(a, b model.AlgorithmEntry) bool {
+		switch column {
+		case algoSortFamily:
+			return a.Family < b.Family
+		case algoSortMode:
+			return a.Mode < b.Mode
+		case algoSortKeySize:
+			return maxKeySize(a) < maxKeySize(b)
+		case algoSortCAVP:
+			return a.CAVPCert < b.CAVPCert
+		case algoSortApproved:
+			return a.Approved && !b.Approved
+		default:
+			return false
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if descending {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+	if descending && column == algoSortListed {
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+	return sorted
+}
+
+func maxKeySize(e model.AlgorithmEntry) int {
+	if len(e.KeySizes) == 0 {
+		return 0
+	}
+	return e.KeySizes[len(e.KeySizes)-1]
+}
+
+// buildAlgorithmTable renders parsed algorithm entries as an aligned table
+func buildAlgorithmTable(entries []model.AlgorithmEntry) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	rows := [][]string{{"Algorithm", "Mode", "Key Sizes", "CAVP", "Status"}}
+	for _, e := range entries {
+		sizes := make([]string, len(e.KeySizes))
+		for i, n := range e.KeySizes {
+			sizes[i] = strconv.Itoa(n)
+		}
+		status := "Approved"
+		if !e.Approved {
+			status = "Non-approved"
+		}
+		rows = append(rows, []string{e.Family, e.Mode, strings.Join(sizes, ", "), e.CAVPCert, status})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	for r, row := range rows {
+		b.WriteString("  ")
+		for i, cell := range row {
+			padded := cell + strings.Repeat(" ", widths[i]-lipgloss.Width(cell))
+			switch {
+			case r == 0:
+				padded = AlgorithmTableHeaderStyle.Render(padded)
+			case i == len(row)-1 && cell != "Approved":
+				padded = NonApprovedStyle.Render(padded)
+			}
+			b.WriteString(padded)
+			if i < len(row)-1 {
+				b.WriteString("  ")
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// refreshAlgorithmTable re-renders the detailed algorithm table for the
+// selected module with the current sort
+func (m *Model) refreshAlgorithmTable() {
+	if m.selectedModule == nil {
+		return
+	}
+	entries := sortAlgorithmEntries(m.selectedModule.AlgorithmEntries(), m.algoSort, m.algoSortDesc)
+	m.algoViewport = viewport.New(m.width-8, 15)
+	m.algoViewport.SetContent(buildAlgorithmTable(entries))
+	m.algoViewportReady = true
+}
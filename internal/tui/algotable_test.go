@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestBuildAlgorithmTable(t *testing.T) {
+	entries := []model.AlgorithmEntry{
+		model.ParseAlgorithm("AES-CBC (A4510)"),
+		model.ParseAlgorithm("RSA KeyGen 2048, 3072 (A4510)"),
+		model.ParseAlgorithm("MD5 (non-approved)"),
+	}
+	content := buildAlgorithmTable(entries)
+
+	for _, want := range []string{"Algorithm", "Key Sizes", "AES", "CBC", "2048, 3072", "A4510", "Approved", "Non-approved"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("table should contain %q:\n%s", want, content)
+		}
+	}
+	if lines := strings.Count(content, "\n"); lines != 4 {
+		t.Errorf("got %d lines, want a header and 3 rows", lines)
+	}
+}
+
+func TestBuildAlgorithmTable_Empty(t *testing.T) {
+	if content := buildAlgorithmTable(nil); content != "" {
+		t.Error("empty algorithms should produce empty content")
+	}
+}
+
+func TestSortAlgorithmEntries(t *testing.T) {
+	entries := []model.AlgorithmEntry{
+		model.ParseAlgorithm("SHA-256 (A2)"),
+		model.ParseAlgorithm("AES-GCM 256 (A3)"),
+		model.ParseAlgorithm("RSA 2048 (A1)"),
+	}
+	families := func(es []model.AlgorithmEntry) string {
+		var names []string
+		for _, e := range es {
+			names = append(names, e.Family)
+		}
+		return strings.Join(names, ",")
+	}
+
+	tests := []struct {
+		column     algoSortColumn
+		descending bool
+		want       string
+	}{
+		{algoSortListed, false, "SHA2-256,AES,RSA"},
+		{algoSortListed, true, "RSA,AES,SHA2-256"},
+		{algoSortFamily, false, "AES,RSA,SHA2-256"},
+		{algoSortCAVP, false, "RSA,SHA2-256,AES"},
+		{algoSortKeySize, true, "RSA,AES,SHA2-256"},
+	}
+	for _, tt := range tests {
+		got := families(sortAlgorithmEntries(entries, tt.column, tt.descending))
+		if got != tt.want {
+			t.Errorf("sort by %s (desc=%v) = %s, want %s", tt.column, tt.descending, got, tt.want)
+		}
+	}
+	if families(entries) != "SHA2-256,AES,RSA" {
+		t.Error("sorting should not modify the input slice")
+	}
+}
+
+func TestModel_AlgorithmTableSortKeys(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.width = 120
+	m.view = ViewDetail
+	m.selectedModule = &model.ModuleItem{Module: model.Module{AlgorithmsDetailed: []string{"SHA-256 (A2)", "AES-CBC (A3)"}}}
+
+	newModel, _ := m.Update(keyMsg("d"))
+	m = newModel.(Model)
+	newModel, _ = m.Update(keyMsg("s"))
+	m = newModel.(Model)
+	if m.algoSort != algoSortFamily {
+		t.Fatalf("algoSort = %s, want algorithm", m.algoSort)
+	}
+	if !strings.Contains(m.View(), "sorted by algorithm") {
+		t.Error("footer should show the sort column")
+	}
+	content := m.algoViewport.View()
+	if strings.Index(content, "AES") > strings.Index(content, "SHA2") {
+		t.Errorf("expected AES before SHA2 after sorting:\n%s", content)
+	}
+
+	newModel, _ = m.Update(keyMsg("S"))
+	m = newModel.(Model)
+	if !m.algoSortDesc {
+		t.Error("expected 'S' to reverse the sort")
+	}
+}
@@ -1,29 +1,68 @@
 package tui
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ethanolivertroy/cmvp-tui/internal/api"
 	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/snapshot"
+	"github.com/ethanolivertroy/cmvp-tui/internal/ui"
+	"github.com/ethanolivertroy/cmvp-tui/internal/watchlist"
 )
 
+// now returns the current time; tests replace it to pin sunset countdowns
+var now = time.Now
+
 // ViewState represents the current view of the application
 type ViewState int
 
 const (
 	ViewList ViewState = iota
 	ViewDetail
+	ViewAlgorithms
+	ViewPQC
+	ViewCompare
+	ViewWatchlist
+	ViewWhatsNew
 )
 
-// ModulesLoadedMsg is sent when modules are loaded from the API
+// ModulesLoadedMsg is sent when modules are loaded from the API or the cache
 type ModulesLoadedMsg struct {
+	Modules     []list.Item
+	GeneratedAt string          // generated_at of the dataset, if known
+	Cached      bool            // true when the modules came from the on-disk cache
+	Failed      *api.FetchError // Datasets that failed to load, if any
+
+	SchemaWarnings []api.SchemaWarning // Upstream schema drift found while decoding
+}
+
+// DatasetLoadedMsg is sent as each dataset finishes downloading
+type DatasetLoadedMsg struct {
+	Progress api.Progress
+}
+
+// DatasetRetriedMsg is sent when a retry of a failed dataset completes
+type DatasetRetriedMsg struct {
+	Dataset api.Dataset
 	Modules []list.Item
+	Err     error
+}
+
+// ModuleDetailMsg is sent when the full record for a module has been fetched
+type ModuleDetailMsg struct {
+	CertificateNumber string
+	Module            *model.Module
+	Err               error
 }
 
 // ErrorMsg is sent when an error occurs
@@ -42,23 +81,78 @@ type Model struct {
 	height            int
 	view              ViewState
 	selectedModule    *model.ModuleItem
-	apiClient         *api.Client
+	source            api.DataSource
 	showAlgoDetails   bool           // Toggle between algorithm categories and detailed list
 	algoViewport      viewport.Model // Viewport for scrolling detailed algorithms
 	algoViewportReady bool           // Whether viewport is initialized
+	algoSort          algoSortColumn // Column the detailed algorithm table is sorted by
+	algoSortDesc      bool           // Whether the algorithm table sort is reversed
+	dataAsOf          string         // generated_at of the data currently shown
+	fromCache         bool           // Whether the list is showing cached data
+	refreshErr        error          // Background refresh failure while showing cached data
+	ctx               context.Context
+	cancel            context.CancelFunc           // Cancels in-flight requests on quit
+	progress          chan api.Progress            // Per-dataset progress from fetchModules
+	datasets          map[api.Dataset]api.Progress // Datasets that have finished loading
+	failed            *api.FetchError              // Datasets missing from the list after a partial failure
+	pendingRetries    int                          // Failed datasets currently being retried
+	details           map[string]model.Module      // Full records fetched from a summary-only source
+	detailLoading     bool                         // Whether the selected module's details are being fetched
+	detailErr         error                        // Failure fetching the selected module's details
+	schemaDrift       []api.SchemaWarning          // Upstream schema drift reported by the source
+	algoList          list.Model                   // Algorithms view
+	pqcViewport       viewport.Model               // Post-quantum readiness view
+	sort              sortMode                     // Order of the module list
+	sortReverse       bool                         // Whether the module list order is reversed
+	tab               statusTab                    // Status the module list is narrowed to
+	facets            facets                       // Values checked in the facet sidebar
+	showFacets        bool                         // Whether the facet sidebar is open
+	sidebarFocused    bool                         // Whether keys move the sidebar cursor instead of the list
+	facetCursor       int                          // Sidebar row under the cursor
+	itemsGen          int                          // Bumped whenever setItems replaces the listed items
+	counts            facetCounts                  // Tab and facet counts for itemsGen and the filter
+	compare           []string                     // Module.Key of each module marked for comparison, oldest first
+	marked            map[string]bool              // Module.Key of each marked module, shared with the delegate
+	compareViewport   viewport.Model               // Compare view
+	detailParent      ViewState                    // View the detail view returns to
+	watchlist         *watchlist.Watchlist         // Watched modules, nil if there is no watchlist file
+	watchErr          error                        // Failure saving the watchlist
+	watchChanged      int                          // Watched modules that changed since the last launch
+	watchList         list.Model                   // Watchlist view
+	exporting         bool                         // Whether the export prompt is open
+	exportPrompt      textinput.Model              // Path to export the visible modules to
+	exportConfirm     string                       // Existing file the next enter in the prompt overwrites
+	exportPath        string                       // Path of the last export
+	toast             string                       // Confirmation shown in the status line
+	toastErr          bool                         // Whether the toast reports a failure
+	toastID           int                          // Identifies the toast a toastExpiredMsg clears
+	snapshotPath      string                       // Last seen snapshot, "" if there is none
+	whatsNew          *snapshot.Diff               // Changes since the last seen snapshot, nil if unknown
+	whatsNewCurrent   *snapshot.Snapshot           // Loaded data, saved as last seen when viewed
+	whatsNewSeen      bool                         // Whether the changes have been viewed
+	whatsNewErr       error                        // Failure comparing with or saving the last seen snapshot
+	whatsNewViewport  viewport.Model               // What's new view
 }
 
-// NewModel creates a new application model
-func NewModel() Model {
+// NewModel creates a new application model that loads its data from source
+func NewModel(source api.DataSource) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(PrimaryColor)
 
+	ctx, cancel := context.WithCancel(context.Background())
+
 	return Model{
-		spinner:   s,
-		loading:   true,
-		view:      ViewList,
-		apiClient: api.NewClient(),
+		spinner:  s,
+		loading:  true,
+		view:     ViewList,
+		source:   source,
+		ctx:      ctx,
+		cancel:   cancel,
+		progress: make(chan api.Progress, len(api.Datasets)),
+		datasets: make(map[api.Dataset]api.Progress),
+		details:  make(map[string]model.Module),
+		marked:   make(map[string]bool),
 	}
 }
 
@@ -66,69 +160,347 @@ func NewModel() Model {
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.spinner.Tick,
+		m.loadCachedModules(),
 		m.fetchModules(),
+		m.waitForProgress(),
 	)
 }
 
-func (m Model) fetchModules() tea.Cmd {
+// loadCachedModules reads the last downloaded datasets from disk so the list
+// can render immediately while fetchModules refreshes in the background
+func (m Model) loadCachedModules() tea.Cmd {
+	cached, ok := m.source.(api.CachedSource)
+	if !ok {
+		return nil
+	}
 	return func() tea.Msg {
-		modules, err := m.apiClient.FetchAllModules()
+		modules, generatedAt, err := cached.CachedModules()
 		if err != nil {
+			return nil
+		}
+		return ModulesLoadedMsg{
+			Modules:     toItems(modules),
+			GeneratedAt: generatedAt,
+			Cached:      true,
+		}
+	}
+}
+
+func (m Model) fetchModules() tea.Cmd {
+	return func() tea.Msg {
+		modules, err := m.source.FetchAllModulesContext(m.ctx, func(p api.Progress) {
+			select {
+			case m.progress <- p:
+			case <-m.ctx.Done():
+			}
+		})
+		// Show whatever loaded; only a total failure is fatal
+		var fetchErr *api.FetchError
+		if err != nil && (!errors.As(err, &fetchErr) || len(modules) == 0) {
 			return ErrorMsg{Err: err}
 		}
+		return ModulesLoadedMsg{
+			Modules:        toItems(modules),
+			GeneratedAt:    m.generatedAt(),
+			Failed:         fetchErr,
+			SchemaWarnings: m.schemaWarnings(),
+		}
+	}
+}
 
-		items := make([]list.Item, len(modules))
-		for i, mod := range modules {
-			items[i] = model.ModuleItem{Module: mod}
+// reload leaves the error screen and starts a fresh load of every dataset
+func (m Model) reload() (Model, tea.Cmd) {
+	m.err = nil
+	m.loading = true
+	m.datasets = make(map[api.Dataset]api.Progress)
+	return m, tea.Batch(
+		m.spinner.Tick,
+		m.fetchModules(),
+		m.waitForProgress(),
+	)
+}
+
+// fetchModuleDetail loads the full record for the selected module when the
+// source's list only carries summary fields
+func (m *Model) fetchModuleDetail() tea.Cmd {
+	m.detailLoading = false
+	src, ok := m.source.(api.SummarySource)
+	if !ok || !src.SummaryOnly() || m.selectedModule == nil {
+		return nil
+	}
+
+	cert := m.selectedModule.CertificateNumber
+	if cert == "" {
+		return nil
+	}
+	if mod, ok := m.details[cert]; ok {
+		m.selectedModule = &model.ModuleItem{Module: mod}
+		return nil
+	}
+
+	m.detailLoading = true
+	source, ctx := m.source, m.ctx
+	return func() tea.Msg {
+		mod, err := source.FetchModuleContext(ctx, cert)
+		return ModuleDetailMsg{CertificateNumber: cert, Module: mod, Err: err}
+	}
+}
+
+// generatedAt returns when the source's most recently fetched data was generated
+func (m Model) generatedAt() string {
+	if src, ok := m.source.(api.GeneratedAtSource); ok {
+		return src.GeneratedAt()
+	}
+	return ""
+}
+
+// schemaWarnings returns the drift the source found in the data it fetched
+func (m Model) schemaWarnings() []api.SchemaWarning {
+	if src, ok := m.source.(api.SchemaReporter); ok {
+		return src.SchemaWarnings()
+	}
+	return nil
+}
+
+// retryFailed refetches only the datasets that failed to load
+func (m Model) retryFailed() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, ds := range m.failed.Failed() {
+		cmds = append(cmds, func() tea.Msg {
+			modules, err := m.source.FetchDatasetContext(m.ctx, ds)
+			return DatasetRetriedMsg{Dataset: ds, Modules: toItems(modules), Err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// waitForProgress delivers the next per-dataset progress report
+func (m Model) waitForProgress() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case p := <-m.progress:
+			return DatasetLoadedMsg{Progress: p}
+		case <-m.ctx.Done():
+			return nil
 		}
-		return ModulesLoadedMsg{Modules: items}
 	}
 }
 
+// datasetItems returns the items that belong to ds
+func datasetItems(items []list.Item, ds api.Dataset) []list.Item {
+	var out []list.Item
+	for _, item := range items {
+		if mi, ok := item.(model.ModuleItem); ok && mi.Status == ds.Status() {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// replaceDataset swaps the items of ds for replacement, keeping the datasets
+// in the same order FetchAllModules returns them
+func replaceDataset(items []list.Item, ds api.Dataset, replacement []list.Item) []list.Item {
+	var out []list.Item
+	for _, d := range api.Datasets {
+		if d == ds {
+			out = append(out, replacement...)
+		} else {
+			out = append(out, datasetItems(items, d)...)
+		}
+	}
+	return out
+}
+
+// toItems wraps modules as list items
+func toItems(modules []model.Module) []list.Item {
+	items := make([]list.Item, len(modules))
+	for i, mod := range modules {
+		items[i] = model.ModuleItem{Module: mod}
+	}
+	return items
+}
+
 // Update handles messages and updates the model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.update(msg)
+	if nm, ok := next.(Model); ok {
+		nm.refreshCounts()
+		return nm, cmd
+	}
+	return next, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.exporting {
+			return m.updateExportPrompt(msg)
+		}
 		// Don't handle keys while filtering
-		if m.list.FilterState() == list.Filtering {
+		if m.filtering() {
 			break
 		}
 
 		switch msg.String() {
 		case "ctrl+c", "q":
 			if m.view == ViewDetail {
+				m.view = m.detailParent
+				return m, nil
+			}
+			if m.view == ViewAlgorithms || m.view == ViewWatchlist || m.view == ViewPQC || m.view == ViewCompare || m.view == ViewWhatsNew {
 				m.view = ViewList
 				return m, nil
 			}
+			m.cancel()
 			return m, tea.Quit
 		case "enter":
 			if m.view == ViewList && !m.loading {
 				if item, ok := m.list.SelectedItem().(model.ModuleItem); ok {
-					m.selectedModule = &item
-					m.view = ViewDetail
-					m.showAlgoDetails = false    // Reset to category view
-					m.algoViewportReady = false  // Reset viewport
-					return m, nil
+					return m.openDetail(item, ViewList)
 				}
 			}
+			if m.view == ViewWatchlist {
+				return m.openWatchedModule()
+			}
+			if m.view == ViewAlgorithms {
+				return m.selectAlgorithm(), nil
+			}
 		case "esc", "backspace":
+			if m.view == ViewList && m.sidebarFocused {
+				m.sidebarFocused = false
+				return m, nil
+			}
 			if m.view == ViewDetail {
+				m.view = m.detailParent
+				return m, nil
+			}
+			if m.view == ViewPQC || m.view == ViewCompare || m.view == ViewWhatsNew {
+				m.view = ViewList
+				return m, nil
+			}
+			if m.view == ViewWatchlist && m.watchList.FilterState() == list.Unfiltered {
+				m.view = ViewList
+				return m, nil
+			}
+			// Esc clears an applied algorithm filter before leaving the view
+			if m.view == ViewAlgorithms && m.algoList.FilterState() == list.Unfiltered {
 				m.view = ViewList
 				return m, nil
 			}
+		case "tab", "shift+tab":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				if msg.String() == "tab" {
+					m.tab = (m.tab + 1) % statusTabs
+				} else {
+					m.tab = (m.tab + statusTabs - 1) % statusTabs
+				}
+				return m, m.setItems(m.allModules)
+			}
+		case "F":
+			// Open the sidebar focused, focus it if the list has focus,
+			// and close it if it already has focus
+			if m.view == ViewList && !m.loading && m.err == nil {
+				if m.showFacets && !m.sidebarFocused {
+					m.sidebarFocused = true
+					return m, nil
+				}
+				m.showFacets = !m.showFacets
+				m.sidebarFocused = m.showFacets
+				m.list.SetSize(m.listWidth(), m.listHeight())
+				return m, nil
+			}
+		case " ":
+			if m.view == ViewList && m.sidebarFocused {
+				m.toggleFacet()
+				return m, m.setItems(m.allModules)
+			}
+		case "m":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				m.toggleMark()
+				return m, nil
+			}
+		case "c":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				return m.openCompare(), nil
+			}
+		case "w":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				if item, ok := m.list.SelectedItem().(model.ModuleItem); ok {
+					m.toggleWatch(item.Module)
+				}
+				return m, nil
+			}
+			if m.view == ViewDetail && m.selectedModule != nil {
+				m.toggleWatch(m.selectedModule.Module)
+				return m, nil
+			}
+			if m.view == ViewWatchlist {
+				return m.unwatchSelected(), nil
+			}
+		case "W":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				return m.openWatchlist(), nil
+			}
+		case "x":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				return m.openExportPrompt()
+			}
+		case "a":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				return m.openAlgorithms(), nil
+			}
+		case "p":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				return m.openPQC(), nil
+			}
+		case "n":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				return m.openWhatsNew()
+			}
 		case "d":
 			if m.view == ViewDetail {
 				m.showAlgoDetails = !m.showAlgoDetails
-				if m.showAlgoDetails && m.selectedModule != nil {
-					// Initialize viewport with algorithm content
-					content := buildAlgorithmContent(m.selectedModule.AlgorithmsDetailed)
-					m.algoViewport = viewport.New(m.width-8, 15)
-					m.algoViewport.SetContent(content)
-					m.algoViewportReady = true
+				if m.showAlgoDetails {
+					m.refreshAlgorithmTable()
 				}
 				return m, nil
 			}
+		case "s", "S":
+			if m.view == ViewList && !m.loading && m.err == nil {
+				if msg.String() == "s" {
+					m.sort = (m.sort + 1) % sortModes
+				} else {
+					m.sortReverse = !m.sortReverse
+				}
+				return m, m.setItems(m.allModules)
+			}
+			if m.view == ViewDetail && m.showAlgoDetails {
+				if msg.String() == "s" {
+					m.algoSort = (m.algoSort + 1) % algoSortColumns
+				} else {
+					m.algoSortDesc = !m.algoSortDesc
+				}
+				m.refreshAlgorithmTable()
+				return m, nil
+			}
+		case "r":
+			if m.err != nil {
+				return m.reload()
+			}
+			if m.view == ViewList && m.failed != nil && m.pendingRetries == 0 {
+				m.pendingRetries = len(m.failed.Errors)
+				return m, m.retryFailed()
+			}
 		case "j", "k", "up", "down":
+			// Move the sidebar cursor instead of the list while it has focus
+			if m.view == ViewList && m.sidebarFocused {
+				if msg.String() == "k" || msg.String() == "up" {
+					m.moveFacetCursor(-1)
+				} else {
+					m.moveFacetCursor(1)
+				}
+				return m, nil
+			}
 			// Pass scroll keys to viewport when showing detailed algorithms
 			if m.view == ViewDetail && m.showAlgoDetails && m.algoViewportReady {
 				var cmd tea.Cmd
@@ -141,7 +513,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		if !m.loading {
-			m.list.SetSize(msg.Width-4, msg.Height-4)
+			m.list.SetSize(m.listWidth(), m.listHeight())
+		}
+		if m.view == ViewAlgorithms {
+			m.algoList.SetSize(msg.Width-4, msg.Height-6)
+		}
+		if m.view == ViewPQC {
+			m.pqcViewport.Width = msg.Width - 4
+			m.pqcViewport.Height = msg.Height - 6
+		}
+		if m.view == ViewWhatsNew {
+			m.whatsNewViewport.Width = msg.Width - 4
+			m.whatsNewViewport.Height = msg.Height - 6
+		}
+		if m.view == ViewCompare {
+			m = m.refreshCompare()
+		}
+		if m.view == ViewWatchlist {
+			m.watchList.SetSize(msg.Width-4, msg.Height-6)
 		}
 		return m, nil
 
@@ -152,45 +541,200 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	case DatasetLoadedMsg:
+		m.datasets[msg.Progress.Dataset] = msg.Progress
+		if len(m.datasets) < len(api.Datasets) {
+			return m, m.waitForProgress()
+		}
+		return m, nil
+
 	case ModulesLoadedMsg:
+		// A slow cache read must not overwrite fresher network data, but
+		// is shown if the network fetch failed before it finished
+		refreshErr := m.err
+		if msg.Cached && refreshErr == nil && !m.loading && !m.fromCache {
+			return m, nil
+		}
+		if refreshErr != nil {
+			m.err = nil
+			m.loading = true // Nothing has been shown yet
+		}
+
+		items := msg.Modules
+		if msg.Failed != nil {
+			// Keep any previously loaded (e.g. cached) copy of failed datasets
+			for _, ds := range msg.Failed.Failed() {
+				items = replaceDataset(items, ds, datasetItems(m.allModules, ds))
+			}
+		}
+
+		m.allModules = items
+		m.dataAsOf = msg.GeneratedAt
+		m.fromCache = msg.Cached
+		m.refreshErr = refreshErr
+		m.failed = msg.Failed
+		m.schemaDrift = msg.SchemaWarnings
+		m.refreshWatchlist()
+
+		// Only complete downloads are compared with the last seen snapshot,
+		// as missing datasets would show up as removed certificates
+		var whatsNew tea.Cmd
+		if !msg.Cached && msg.Failed == nil {
+			whatsNew = m.compareSnapshot()
+		}
+
+		// Refresh the existing list in place so filters and selection survive
+		if !m.loading {
+			m.list.SetSize(m.listWidth(), m.listHeight())
+			if m.view == ViewCompare {
+				m = m.refreshCompare()
+			}
+			return m, tea.Batch(m.setItems(items), whatsNew)
+		}
 		m.loading = false
-		m.allModules = msg.Modules
 
 		delegate := NewModuleDelegate()
-		m.list = list.New(msg.Modules, delegate, m.width-4, m.height-4)
+		delegate.Marked = m.marked
+		delegate.Watchlist = m.watchlist
+		m.list = list.New(nil, delegate, m.listWidth(), m.listHeight())
 		m.list.Title = "NIST CMVP Modules"
 		m.list.SetShowStatusBar(true)
 		m.list.SetFilteringEnabled(true)
 		m.list.Styles.Title = TitleStyle
 		m.list.FilterInput.Prompt = "Filter: "
 		m.list.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+		m.list.AdditionalShortHelpKeys = facetHelpKeys
+		m.list.AdditionalFullHelpKeys = facetFullHelpKeys
 
-		// Use exact substring matching instead of fuzzy matching
-		m.list.Filter = func(term string, targets []string) []list.Rank {
-			var ranks []list.Rank
-			term = strings.ToLower(term)
-			for i, target := range targets {
-				if strings.Contains(strings.ToLower(target), term) {
-					ranks = append(ranks, list.Rank{Index: i})
-				}
+		return m, tea.Batch(m.setItems(items), whatsNew)
+
+	case DatasetRetriedMsg:
+		m.pendingRetries--
+		if m.failed == nil {
+			return m, nil
+		}
+
+		var remaining []*api.DatasetError
+		for _, dsErr := range m.failed.Errors {
+			if dsErr.Dataset != msg.Dataset {
+				remaining = append(remaining, dsErr)
+			} else if msg.Err != nil {
+				remaining = append(remaining, &api.DatasetError{Dataset: msg.Dataset, Err: msg.Err})
 			}
-			return ranks
 		}
+		if len(remaining) == 0 {
+			m.failed = nil
+		} else {
+			m.failed = &api.FetchError{Errors: remaining}
+		}
+		m.list.SetSize(m.listWidth(), m.listHeight())
 
+		if msg.Err != nil {
+			return m, nil
+		}
+		m.allModules = replaceDataset(m.allModules, msg.Dataset, msg.Modules)
+		m.refreshWatchlist()
+		if m.view == ViewCompare {
+			m = m.refreshCompare()
+		}
+		var whatsNew tea.Cmd
+		if m.failed == nil && !m.fromCache {
+			whatsNew = m.compareSnapshot()
+		}
+		return m, tea.Batch(m.setItems(m.allModules), whatsNew)
+
+	case ModuleDetailMsg:
+		if msg.Err != nil {
+			if m.selectedModule != nil && m.selectedModule.CertificateNumber == msg.CertificateNumber {
+				m.detailLoading = false
+				m.detailErr = msg.Err
+			}
+			return m, nil
+		}
+		m.details[msg.CertificateNumber] = *msg.Module
+		if m.selectedModule != nil && m.selectedModule.CertificateNumber == msg.CertificateNumber {
+			m.detailLoading = false
+			m.selectedModule = &model.ModuleItem{Module: *msg.Module}
+		}
+		return m, nil
+
+	case ExportedMsg:
+		if msg.Err != nil {
+			return m.showToast(fmt.Sprintf("Export failed: %v", msg.Err), true)
+		}
+		noun := "modules"
+		if msg.Count == 1 {
+			noun = "module"
+		}
+		return m.showToast(fmt.Sprintf("Exported %d %s to %s", msg.Count, noun, msg.Path), false)
+
+	case WhatsNewMsg:
+		m.whatsNew = msg.Diff
+		m.whatsNewCurrent = msg.Current
+		m.whatsNewSeen = msg.Diff == nil
+		m.whatsNewErr = msg.Err
+		return m, nil
+
+	case snapshotSavedMsg:
+		if msg.Err != nil {
+			m.whatsNewErr = msg.Err
+		}
+		return m, nil
+
+	case toastExpiredMsg:
+		if msg.id == m.toastID {
+			m.toast = ""
+		}
 		return m, nil
 
 	case ErrorMsg:
+		// Keep showing cached data if the background refresh fails
+		if !m.loading && m.fromCache {
+			m.refreshErr = msg.Err
+			return m, nil
+		}
 		m.loading = false
 		m.err = msg.Err
 		return m, nil
 	}
 
-	// Pass messages to list component when in list view
+	// Pass messages to the list of the current view. Only one list gets
+	// them, as filter results are not addressed to a particular list.
+	if m.exporting {
+		var cmd tea.Cmd
+		m.exportPrompt, cmd = m.exportPrompt.Update(msg)
+		return m, cmd
+	}
 	if m.view == ViewList && !m.loading && m.err == nil {
 		var cmd tea.Cmd
 		m.list, cmd = m.list.Update(msg)
 		return m, cmd
 	}
+	if m.view == ViewAlgorithms {
+		var cmd tea.Cmd
+		m.algoList, cmd = m.algoList.Update(msg)
+		return m, cmd
+	}
+	if m.view == ViewPQC {
+		var cmd tea.Cmd
+		m.pqcViewport, cmd = m.pqcViewport.Update(msg)
+		return m, cmd
+	}
+	if m.view == ViewWhatsNew {
+		var cmd tea.Cmd
+		m.whatsNewViewport, cmd = m.whatsNewViewport.Update(msg)
+		return m, cmd
+	}
+	if m.view == ViewCompare {
+		var cmd tea.Cmd
+		m.compareViewport, cmd = m.compareViewport.Update(msg)
+		return m, cmd
+	}
+	if m.view == ViewWatchlist {
+		var cmd tea.Cmd
+		m.watchList, cmd = m.watchList.Update(msg)
+		return m, cmd
+	}
 
 	return m, nil
 }
@@ -199,14 +743,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) View() string {
 	if m.loading {
 		return AppStyle.Render(
-			fmt.Sprintf("\n\n   %s Loading CMVP modules...\n\n", m.spinner.View()),
+			fmt.Sprintf("\n\n   %s Loading CMVP modules...\n\n%s", m.spinner.View(), m.renderDatasetProgress()),
 		)
 	}
 
 	if m.err != nil {
 		return AppStyle.Render(
 			lipgloss.NewStyle().Foreground(ErrorColor).Render(
-				fmt.Sprintf("\n\n   Error: %v\n\n   Press q to quit.", m.err),
+				fmt.Sprintf("\n\n   Error: %v\n\n   Press r to retry • q to quit.", m.err),
 			),
 		)
 	}
@@ -214,11 +758,166 @@ func (m Model) View() string {
 	switch m.view {
 	case ViewDetail:
 		return m.renderDetailView()
+	case ViewAlgorithms:
+		return m.renderAlgorithmsView()
+	case ViewPQC:
+		return m.renderPQCView()
+	case ViewWhatsNew:
+		return m.renderWhatsNewView()
+	case ViewCompare:
+		return m.renderCompareView()
+	case ViewWatchlist:
+		return m.renderWatchlistView()
 	default:
-		return AppStyle.Render(m.list.View())
+		body := m.list.View()
+		if m.showFacets {
+			body = lipgloss.JoinHorizontal(lipgloss.Top, m.renderFacetSidebar(m.listHeight()), body)
+		}
+		return AppStyle.Render(m.renderWarningBanner() + m.renderTabs() + "\n" + body + "\n" + m.renderStatusLine())
 	}
 }
 
+// filtering reports whether the current view's list is taking filter input
+func (m Model) filtering() bool {
+	switch m.view {
+	case ViewList:
+		return m.list.FilterState() == list.Filtering
+	case ViewAlgorithms:
+		return m.algoList.FilterState() == list.Filtering
+	case ViewWatchlist:
+		return m.watchList.FilterState() == list.Filtering
+	default:
+		return false
+	}
+}
+
+// listWidth returns the width available to the list beside any sidebar
+func (m Model) listWidth() int {
+	if m.showFacets {
+		return m.width - 4 - facetSidebarWidth
+	}
+	return m.width - 4
+}
+
+// listHeight returns the height available to the list below any banner
+func (m Model) listHeight() int {
+	h := m.height - 6 // Padding, tabs and status line
+	if banner := m.renderWarningBanner(); banner != "" {
+		h -= lipgloss.Height(banner) - 1
+	}
+	return h
+}
+
+// renderWarningBanner reports datasets that failed to load, if any
+func (m Model) renderWarningBanner() string {
+	if m.failed == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, dsErr := range m.failed.Errors {
+		b.WriteString(WarningBannerStyle.Render(fmt.Sprintf("⚠ Could not load %s: %v", dsErr.Dataset, dsErr.Err)))
+		b.WriteString("\n")
+	}
+	if m.pendingRetries > 0 {
+		b.WriteString(HelpStyle.UnsetMarginTop().Render("  Retrying…"))
+	} else {
+		b.WriteString(HelpStyle.UnsetMarginTop().Render("  Press r to retry"))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// renderDatasetProgress lists which datasets have arrived while loading
+func (m Model) renderDatasetProgress() string {
+	var b strings.Builder
+	for _, ds := range api.Datasets {
+		p, done := m.datasets[ds]
+		switch {
+		case !done:
+			b.WriteString(HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("   … %s", ds)))
+		case p.Err != nil:
+			b.WriteString(lipgloss.NewStyle().Foreground(ErrorColor).Render(fmt.Sprintf("   ✗ %s", ds)))
+		default:
+			b.WriteString(lipgloss.NewStyle().Foreground(SecondaryColor).Render(fmt.Sprintf("   ✓ %s (%d)", ds, p.Count)))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderStatusLine shows how fresh the listed data is, or what is wrong
+// with the filter query
+func (m Model) renderStatusLine() string {
+	if m.exporting {
+		return m.renderExportPrompt()
+	}
+	if err := m.queryError(); err != nil {
+		return QueryErrorStyle.Render("Invalid query: " + err.Error())
+	}
+
+	status := "Data as of " + formatDataAsOf(m.dataAsOf)
+	if m.fromCache {
+		if m.refreshErr != nil {
+			status += fmt.Sprintf(" (cached; refresh failed: %v)", m.refreshErr)
+		} else {
+			status += " (cached, refreshing…)"
+		}
+	}
+	if m.sort != sortDefault || m.sortReverse {
+		status += fmt.Sprintf(" • Sorted by %s, %s", m.sort, m.sort.order(m.sortReverse))
+	}
+	if m.watchChanged > 0 {
+		status += fmt.Sprintf(" • %d watched module(s) changed (W)", m.watchChanged)
+	}
+	if m.watchErr != nil {
+		status += fmt.Sprintf(" • Watchlist not saved: %v", m.watchErr)
+	}
+	if m.whatsNew != nil && !m.whatsNew.Empty() && !m.whatsNewSeen {
+		status += fmt.Sprintf(" • %d certificate(s) changed since you last looked (n)", m.whatsNew.Len())
+	}
+	switch n := len(m.compare); {
+	case n == 1:
+		status += " • 1 marked (mark another to compare)"
+	case n > 1:
+		status += fmt.Sprintf(" • %d marked (c: compare)", n)
+	}
+	line := m.renderToast() + StatusBarStyle.Render(status)
+	if n := len(m.schemaDrift); n > 0 {
+		line += WarningBannerStyle.Render(fmt.Sprintf("⚠ %d schema warning(s); run cmvp doctor", n))
+	}
+	return line
+}
+
+// formatDataAsOf renders a generated_at timestamp for display
+func formatDataAsOf(generatedAt string) string {
+	if generatedAt == "" {
+		return "unknown"
+	}
+	t, err := time.Parse(time.RFC3339, generatedAt)
+	if err != nil {
+		return generatedAt
+	}
+	return t.Format("Jan 2, 2006 15:04 MST")
+}
+
+// openDetail shows the detail view of item, returning to parent on esc
+func (m Model) openDetail(item model.ModuleItem, parent ViewState) (Model, tea.Cmd) {
+	m.selectedModule = &item
+	m.view = ViewDetail
+	m.detailParent = parent
+	m.showAlgoDetails = false   // Reset to category view
+	m.algoViewportReady = false // Reset viewport
+	m.detailErr = nil
+	return m, m.fetchModuleDetail()
+}
+
+// detailFields returns the rows of the details grid for mod, with the
+// sunset countdown badge after the sunset date
+func detailFields(mod model.Module) []ui.DetailField {
+	return ui.DetailFields(mod, SunsetBadge(mod, now()))
+}
+
 func (m Model) renderDetailView() string {
 	if m.selectedModule == nil {
 		return ""
@@ -232,12 +931,24 @@ func (m Model) renderDetailView() string {
 	b.WriteString(DetailTitleStyle.Render(mod.ModuleName))
 	b.WriteString("  ")
 	b.WriteString(StatusBadge(mod.Status))
-	if mod.OverallLevel > 0 {
+	if badge := LevelBadge(mod.OverallLevel); badge != "" {
 		b.WriteString("  ")
-		b.WriteString(LevelBadge(mod.OverallLevel))
+		b.WriteString(badge)
+	}
+	if m.watchlist.Contains(mod.Key()) {
+		b.WriteString("  ")
+		b.WriteString(WatchedBadge.Render("★ Watching"))
 	}
 	b.WriteString("\n\n")
 
+	if m.detailLoading {
+		b.WriteString(HelpStyle.UnsetMarginTop().Render("Loading certificate details…"))
+		b.WriteString("\n\n")
+	} else if m.detailErr != nil {
+		b.WriteString(lipgloss.NewStyle().Foreground(ErrorColor).Render(fmt.Sprintf("Could not load certificate details: %v", m.detailErr)))
+		b.WriteString("\n\n")
+	}
+
 	// Caveat warning (displayed prominently if present)
 	if mod.Caveat != "" {
 		b.WriteString(DetailLabelStyle.Render("CAVEAT:"))
@@ -247,67 +958,29 @@ func (m Model) renderDetailView() string {
 	}
 
 	// Details grid
-	details := []struct {
-		label string
-		value string
-		isURL bool
-	}{
-		{"Certificate #:", mod.CertificateNumber, false},
-		{"Vendor:", mod.VendorName, false},
-		{"Module Type:", mod.ModuleType, false},
-		{"Standard:", mod.Standard, false},
-		{"Embodiment:", mod.Embodiment, false},
-		{"Lab:", mod.Lab, false},
-	}
-
-	// Add validation date if available
-	if !mod.ValidationDate.IsZero() {
-		details = append(details, struct {
-			label string
-			value string
-			isURL bool
-		}{"Validation Date:", mod.ValidationDate.Format("January 2, 2006"), false})
-	}
-
-	// Add sunset date if available
-	if mod.SunsetDate != "" {
-		details = append(details, struct {
-			label string
-			value string
-			isURL bool
-		}{"Sunset Date:", mod.SunsetDate, false})
-	}
-
-	// Add URLs
-	if mod.CertificateURL != "" {
-		details = append(details, struct {
-			label string
-			value string
-			isURL bool
-		}{"NIST URL:", mod.CertificateURL, true})
-	}
-
-	if mod.SecurityPolicyURL != "" {
-		details = append(details, struct {
-			label string
-			value string
-			isURL bool
-		}{"Security Policy:", mod.SecurityPolicyURL, true})
-	}
-
-	for _, d := range details {
-		if d.value == "" {
+	for _, d := range detailFields(mod.Module) {
+		if d.Value == "" || d.Badged {
 			continue
 		}
-		b.WriteString(DetailLabelStyle.Render(d.label))
-		if d.isURL {
-			b.WriteString(DetailURLStyle.Render(d.value))
+		b.WriteString(DetailLabelStyle.Render(d.Label))
+		if d.IsURL {
+			b.WriteString(DetailURLStyle.Render(d.Value))
 		} else {
-			b.WriteString(DetailValueStyle.Render(d.value))
+			b.WriteString(DetailValueStyle.Render(d.Value))
 		}
 		b.WriteString("\n")
 	}
 
+	// Per-area security levels (if published instead of an overall level)
+	if len(mod.OverallLevel.Areas) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render("Levels by Area:"))
+		b.WriteString("\n")
+		for _, area := range mod.OverallLevel.Areas {
+			b.WriteString(fmt.Sprintf("  %s  %s\n", LevelBadge(model.NewSecurityLevel(area.Level)), area.Area))
+		}
+	}
+
 	// Description (if available)
 	if mod.Module.Description != "" {
 		b.WriteString("\n")
@@ -324,7 +997,12 @@ func (m Model) renderDetailView() string {
 		b.WriteString("\n")
 		if len(mod.AlgorithmsDetailed) > 0 && m.algoViewportReady {
 			b.WriteString(m.algoViewport.View())
-			b.WriteString(fmt.Sprintf("\n  %d algorithms (scroll with j/k or arrows)\n", len(mod.AlgorithmsDetailed)))
+			order := "↑"
+			if m.algoSortDesc {
+				order = "↓"
+			}
+			b.WriteString(fmt.Sprintf("\n  %d algorithms, sorted by %s %s (scroll with j/k or arrows • s: sort • S: reverse)\n",
+				len(mod.AlgorithmsDetailed), m.algoSort, order))
 		} else if len(mod.AlgorithmsDetailed) == 0 {
 			b.WriteString(HelpStyle.Render("  (No detailed algorithm data available yet)"))
 			b.WriteString("\n")
@@ -340,18 +1018,11 @@ func (m Model) renderDetailView() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(HelpStyle.Render("Press ESC or Backspace to return to list • Press d to toggle algorithm details"))
+	help := "Press ESC or Backspace to return to list • Press d to toggle algorithm details • Press w to watch"
+	if m.watchErr != nil {
+		help += fmt.Sprintf(" • Watchlist not saved: %v", m.watchErr)
+	}
+	b.WriteString(HelpStyle.Render(help))
 
 	return AppStyle.Render(b.String())
 }
-
-// buildAlgorithmContent creates the content string for the algorithm viewport
-func buildAlgorithmContent(algorithms []string) string {
-	var b strings.Builder
-	for _, algo := range algorithms {
-		b.WriteString("  • ")
-		b.WriteString(algo)
-		b.WriteString("\n")
-	}
-	return b.String()
-}
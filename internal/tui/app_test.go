@@ -3,14 +3,28 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
 	"github.com/ethanolivertroy/cmvp-tui/internal/model"
 )
 
+// newTestModel creates a model backed by an empty in-memory data source
+func newTestModel() Model {
+	return NewModel(api.NewMemorySource(nil, api.MetadataJSON{}))
+}
+
+// pinNow fixes the clock used for sunset countdowns for the rest of the test
+func pinNow(t *testing.T, at time.Time) {
+	t.Helper()
+	now = func() time.Time { return at }
+	t.Cleanup(func() { now = time.Now })
+}
+
 func TestNewModel(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 
 	if !m.loading {
 		t.Error("expected loading to be true")
@@ -18,8 +32,8 @@ func TestNewModel(t *testing.T) {
 	if m.view != ViewList {
 		t.Errorf("expected view to be ViewList, got %v", m.view)
 	}
-	if m.apiClient == nil {
-		t.Error("expected apiClient to be initialized")
+	if m.source == nil {
+		t.Error("expected source to be initialized")
 	}
 	if m.err != nil {
 		t.Error("expected err to be nil")
@@ -27,7 +41,7 @@ func TestNewModel(t *testing.T) {
 }
 
 func TestModel_Init(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	cmd := m.Init()
 
 	if cmd == nil {
@@ -35,8 +49,35 @@ func TestModel_Init(t *testing.T) {
 	}
 }
 
+func TestModel_FetchModules_FromSource(t *testing.T) {
+	src := api.NewMemorySource([]model.Module{
+		{CertificateNumber: "1", ModuleName: "Active", Status: model.StatusActive},
+		{ModuleName: "Pending", Status: model.StatusInProcess},
+	}, api.MetadataJSON{GeneratedAt: "2024-01-15T10:00:00Z"})
+	m := NewModel(src)
+
+	msg, ok := m.fetchModules()().(ModulesLoadedMsg)
+	if !ok {
+		t.Fatal("expected fetchModules to produce ModulesLoadedMsg")
+	}
+	if len(msg.Modules) != 2 {
+		t.Errorf("got %d modules, want 2", len(msg.Modules))
+	}
+	if msg.GeneratedAt != "2024-01-15T10:00:00Z" {
+		t.Errorf("GeneratedAt = %q, want the source's generated_at", msg.GeneratedAt)
+	}
+	if msg.Cached {
+		t.Error("expected fetched modules not to be marked cached")
+	}
+
+	// MemorySource has no offline cache
+	if m.loadCachedModules() != nil {
+		t.Error("expected no cache load for a source without a cache")
+	}
+}
+
 func TestModel_Update_QuitKey(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 
 	// Test 'q' key quits from list view
@@ -48,8 +89,38 @@ func TestModel_Update_QuitKey(t *testing.T) {
 	}
 }
 
+func TestModel_Update_QuitCancelsRequests(t *testing.T) {
+	m := newTestModel()
+
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}
+	m.Update(msg)
+
+	if m.ctx.Err() == nil {
+		t.Error("expected quitting to cancel in-flight requests")
+	}
+}
+
+func TestModel_Update_DatasetLoadedMsg(t *testing.T) {
+	m := newTestModel()
+
+	msg := DatasetLoadedMsg{Progress: api.Progress{Dataset: api.DatasetActive, Count: 42}}
+	newModel, cmd := m.Update(msg)
+	updated := newModel.(Model)
+
+	if cmd == nil {
+		t.Error("expected to keep waiting for the remaining datasets")
+	}
+	view := updated.View()
+	if !strings.Contains(view, "active modules (42)") {
+		t.Error("loading view should show the loaded active dataset")
+	}
+	if !strings.Contains(view, "… historical modules") {
+		t.Error("loading view should show historical modules as pending")
+	}
+}
+
 func TestModel_Update_QuitFromDetail(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.selectedModule = &model.ModuleItem{}
@@ -65,7 +136,7 @@ func TestModel_Update_QuitFromDetail(t *testing.T) {
 }
 
 func TestModel_Update_EscapeFromDetail(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.selectedModule = &model.ModuleItem{}
@@ -81,7 +152,7 @@ func TestModel_Update_EscapeFromDetail(t *testing.T) {
 }
 
 func TestModel_Update_ToggleAlgoDetails(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.selectedModule = &model.ModuleItem{
@@ -103,7 +174,7 @@ func TestModel_Update_ToggleAlgoDetails(t *testing.T) {
 }
 
 func TestModel_Update_ModulesLoadedMsg(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.width = 80
 	m.height = 24
 
@@ -129,8 +200,252 @@ func TestModel_Update_ModulesLoadedMsg(t *testing.T) {
 	}
 }
 
+func TestModel_Update_CachedThenFresh(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	cached := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "Cached Module"}},
+	}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: cached, GeneratedAt: "2024-01-15T10:00:00Z", Cached: true})
+	m = newModel.(Model)
+
+	if m.loading {
+		t.Error("expected cached modules to end loading")
+	}
+	if !m.fromCache {
+		t.Error("expected fromCache to be true")
+	}
+	if !strings.Contains(m.View(), "cached") {
+		t.Error("expected status line to mention cached data")
+	}
+
+	fresh := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "Fresh Module"}},
+		model.ModuleItem{Module: model.Module{ModuleName: "Another Module"}},
+	}
+	newModel, _ = m.Update(ModulesLoadedMsg{Modules: fresh, GeneratedAt: "2024-02-01T10:00:00Z"})
+	m = newModel.(Model)
+
+	if m.fromCache {
+		t.Error("expected fromCache to be false after fresh data")
+	}
+	if len(m.list.Items()) != 2 {
+		t.Errorf("expected list to hold 2 fresh items, got %d", len(m.list.Items()))
+	}
+	if m.dataAsOf != "2024-02-01T10:00:00Z" {
+		t.Errorf("dataAsOf = %q, want 2024-02-01T10:00:00Z", m.dataAsOf)
+	}
+}
+
+func TestModel_Update_SchemaWarnings(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	items := []list.Item{model.ModuleItem{Module: model.Module{ModuleName: "Module"}}}
+	newModel, _ := m.Update(ModulesLoadedMsg{
+		Modules: items,
+		SchemaWarnings: []api.SchemaWarning{
+			{Dataset: api.DatasetActive, Issue: api.IssueUnknownKey, Key: "Lab Name", Count: 10},
+			{Dataset: api.DatasetActive, Issue: api.IssueMissingKey, Key: "lab", Count: 10},
+		},
+	})
+	m = newModel.(Model)
+
+	if !strings.Contains(m.View(), "2 schema warning(s); run cmvp doctor") {
+		t.Error("expected status line to report schema warnings")
+	}
+
+	newModel, _ = m.Update(ModulesLoadedMsg{Modules: items})
+	m = newModel.(Model)
+	if strings.Contains(m.View(), "schema warning") {
+		t.Error("expected schema notice to clear when the data validates")
+	}
+}
+
+func TestModel_Update_LateCacheIgnored(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	fresh := []list.Item{model.ModuleItem{Module: model.Module{ModuleName: "Fresh Module"}}}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: fresh})
+	m = newModel.(Model)
+
+	cached := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "Stale 1"}},
+		model.ModuleItem{Module: model.Module{ModuleName: "Stale 2"}},
+	}
+	newModel, _ = m.Update(ModulesLoadedMsg{Modules: cached, Cached: true})
+	m = newModel.(Model)
+
+	if m.fromCache || len(m.allModules) != 1 {
+		t.Error("expected late cached data not to replace fresh data")
+	}
+}
+
+func TestModel_Update_RefreshErrorKeepsCache(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	cached := []list.Item{model.ModuleItem{Module: model.Module{ModuleName: "Cached Module"}}}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: cached, Cached: true})
+	m = newModel.(Model)
+
+	newModel, _ = m.Update(ErrorMsg{Err: &testError{}})
+	m = newModel.(Model)
+
+	if m.err != nil {
+		t.Error("expected refresh error not to replace the cached list")
+	}
+	if m.refreshErr == nil {
+		t.Error("expected refreshErr to be set")
+	}
+	if !strings.Contains(m.View(), "refresh failed") {
+		t.Error("expected status line to report the failed refresh")
+	}
+}
+
+func TestModel_Update_CacheAfterError(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	// Offline, the network fetch can fail before the cache read finishes
+	newModel, _ := m.Update(ErrorMsg{Err: &testError{}})
+	m = newModel.(Model)
+	cached := []list.Item{model.ModuleItem{Module: model.Module{ModuleName: "Cached Module"}}}
+	newModel, _ = m.Update(ModulesLoadedMsg{Modules: cached, Cached: true})
+	m = newModel.(Model)
+
+	if m.err != nil || m.loading {
+		t.Fatalf("expected the cached list instead of the error screen (err = %v, loading = %v)", m.err, m.loading)
+	}
+	if !m.fromCache || len(m.list.Items()) != 1 {
+		t.Errorf("expected the cached module to be listed, got %d items", len(m.list.Items()))
+	}
+	if !strings.Contains(m.View(), "refresh failed") {
+		t.Error("expected status line to report the failed refresh")
+	}
+}
+
+func TestModel_PartialFailure_RetryDataset(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	loaded := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "Active", Status: model.StatusActive}},
+		model.ModuleItem{Module: model.Module{ModuleName: "In Process", Status: model.StatusInProcess}},
+	}
+	failed := &api.FetchError{Errors: []*api.DatasetError{
+		{Dataset: api.DatasetHistorical, Err: &testError{}},
+	}}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: loaded, Failed: failed})
+	m = newModel.(Model)
+
+	if m.err != nil {
+		t.Fatal("expected a partial failure not to show the error screen")
+	}
+	view := m.View()
+	if !strings.Contains(view, "Could not load historical modules") {
+		t.Error("expected warning banner naming the failed dataset")
+	}
+	if !strings.Contains(view, "Press r to retry") {
+		t.Error("expected warning banner to offer a retry")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newModel.(Model)
+	if cmd == nil || m.pendingRetries != 1 {
+		t.Fatalf("expected r to retry 1 dataset, pendingRetries = %d", m.pendingRetries)
+	}
+
+	retried := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "Historical", Status: model.StatusHistorical}},
+	}
+	newModel, _ = m.Update(DatasetRetriedMsg{Dataset: api.DatasetHistorical, Modules: retried})
+	m = newModel.(Model)
+
+	if m.failed != nil {
+		t.Error("expected failed datasets to be cleared after a successful retry")
+	}
+	if len(m.allModules) != 3 {
+		t.Fatalf("expected 3 modules after retry, got %d", len(m.allModules))
+	}
+	if name := m.allModules[1].(model.ModuleItem).ModuleName; name != "Historical" {
+		t.Errorf("expected retried dataset in dataset order, got %q second", name)
+	}
+}
+
+func TestModel_PartialFailure_RetryFails(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	failed := &api.FetchError{Errors: []*api.DatasetError{
+		{Dataset: api.DatasetInProcess, Err: &testError{}},
+	}}
+	loaded := []list.Item{model.ModuleItem{Module: model.Module{ModuleName: "Active"}}}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: loaded, Failed: failed})
+	m = newModel.(Model)
+	m.pendingRetries = 1
+
+	newModel, _ = m.Update(DatasetRetriedMsg{Dataset: api.DatasetInProcess, Err: &testError{}})
+	m = newModel.(Model)
+
+	if m.failed == nil || len(m.failed.Errors) != 1 {
+		t.Error("expected the dataset to stay failed")
+	}
+	if m.pendingRetries != 0 {
+		t.Errorf("pendingRetries = %d, want 0", m.pendingRetries)
+	}
+}
+
+func TestReplaceDataset(t *testing.T) {
+	items := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "A", Status: model.StatusActive}},
+		model.ModuleItem{Module: model.Module{ModuleName: "H1", Status: model.StatusHistorical}},
+		model.ModuleItem{Module: model.Module{ModuleName: "P", Status: model.StatusInProcess}},
+	}
+	replacement := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "H2", Status: model.StatusHistorical}},
+		model.ModuleItem{Module: model.Module{ModuleName: "H3", Status: model.StatusHistorical}},
+	}
+
+	got := replaceDataset(items, api.DatasetHistorical, replacement)
+
+	var names []string
+	for _, item := range got {
+		names = append(names, item.(model.ModuleItem).ModuleName)
+	}
+	if strings.Join(names, ",") != "A,H2,H3,P" {
+		t.Errorf("replaceDataset() = %v, want [A H2 H3 P]", names)
+	}
+}
+
+func TestFormatDataAsOf(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", "unknown"},
+		{"2024-01-15T10:00:00Z", "Jan 15, 2024 10:00 UTC"},
+		{"not a timestamp", "not a timestamp"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDataAsOf(tt.input); got != tt.want {
+			t.Errorf("formatDataAsOf(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
 func TestModel_Update_ErrorMsg(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 
 	msg := ErrorMsg{Err: &testError{}}
 	newModel, _ := m.Update(msg)
@@ -145,7 +460,7 @@ func TestModel_Update_ErrorMsg(t *testing.T) {
 }
 
 func TestModel_Update_WindowSizeMsg(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	// Keep loading=true to avoid list.SetSize on uninitialized list
 
 	msg := tea.WindowSizeMsg{Width: 100, Height: 50}
@@ -161,7 +476,7 @@ func TestModel_Update_WindowSizeMsg(t *testing.T) {
 }
 
 func TestModel_Update_WindowSizeMsg_WithList(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.width = 80
 	m.height = 24
 
@@ -191,7 +506,7 @@ func TestModel_Update_WindowSizeMsg_WithList(t *testing.T) {
 }
 
 func TestModel_View_Loading(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = true
 
 	view := m.View()
@@ -202,7 +517,7 @@ func TestModel_View_Loading(t *testing.T) {
 }
 
 func TestModel_View_Error(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.err = &testError{}
 
@@ -213,6 +528,26 @@ func TestModel_View_Error(t *testing.T) {
 	}
 }
 
+func TestModel_ErrorView_Retry(t *testing.T) {
+	m := newTestModel()
+	newModel, _ := m.Update(ErrorMsg{Err: &testError{}})
+	m = newModel.(Model)
+
+	if !strings.Contains(m.View(), "r to retry") {
+		t.Error("expected error view to offer a retry")
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = newModel.(Model)
+
+	if cmd == nil {
+		t.Error("expected r to start a new fetch")
+	}
+	if m.err != nil || !m.loading {
+		t.Error("expected r to clear the error and return to the loading screen")
+	}
+}
+
 func TestViewState_Constants(t *testing.T) {
 	if ViewList != 0 {
 		t.Errorf("expected ViewList to be 0, got %d", ViewList)
@@ -223,7 +558,7 @@ func TestViewState_Constants(t *testing.T) {
 }
 
 func TestModel_Update_EnterKey_SelectsModule(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.width = 80
 	m.height = 24
 
@@ -254,7 +589,7 @@ func TestModel_Update_EnterKey_SelectsModule(t *testing.T) {
 }
 
 func TestModel_Update_BackspaceFromDetail(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.selectedModule = &model.ModuleItem{}
@@ -269,7 +604,7 @@ func TestModel_Update_BackspaceFromDetail(t *testing.T) {
 }
 
 func TestModel_View_DetailView(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.width = 80
@@ -282,7 +617,7 @@ func TestModel_View_DetailView(t *testing.T) {
 			ModuleType:        "Hardware",
 			Status:            model.StatusActive,
 			Standard:          "FIPS 140-3",
-			OverallLevel:      2,
+			OverallLevel:      model.NewSecurityLevel(2),
 			Algorithms:        []string{"AES", "SHA-256"},
 		},
 	}
@@ -298,8 +633,77 @@ func TestModel_View_DetailView(t *testing.T) {
 	}
 }
 
+func TestModel_View_DetailView_Sunset(t *testing.T) {
+	pinNow(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	m := newTestModel()
+	m.loading = false
+	m.view = ViewDetail
+	m.width = 120
+	m.height = 40
+	m.selectedModule = &model.ModuleItem{Module: model.Module{
+		ModuleName: "Expiring Module",
+		Status:     model.StatusActive,
+		SunsetDate: time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	view := m.View()
+	if !strings.Contains(view, "March 1, 2027") {
+		t.Error("detail view should show the sunset date")
+	}
+	if !strings.Contains(view, "Sunset in 135 days") {
+		t.Error("detail view should show the sunset countdown")
+	}
+}
+
+func TestModel_View_DetailView_InProcess(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.view = ViewDetail
+	m.width = 120
+	m.height = 40
+	m.selectedModule = &model.ModuleItem{Module: model.Module{
+		ModuleName: "Queued Module",
+		Standard:   "FIPS 140-3",
+		Status:     model.StatusInProcess,
+		Stage:      model.StageCoordination,
+	}}
+
+	view := m.View()
+	if !strings.Contains(view, "Review Stage:") || !strings.Contains(view, "Coordination") {
+		t.Error("detail view should show the review stage of in-process modules")
+	}
+}
+
+func TestModel_View_DetailView_Levels(t *testing.T) {
+	m := newTestModel()
+	m.loading = false
+	m.view = ViewDetail
+	m.width = 120
+	m.height = 40
+
+	m.selectedModule = &model.ModuleItem{Module: model.Module{
+		ModuleName:   "Text Level Module",
+		OverallLevel: model.ParseSecurityLevel("Tested Configuration(s)"),
+	}}
+	if view := m.View(); !strings.Contains(view, "Overall Level:") || !strings.Contains(view, "Tested Configuration(s)") {
+		t.Error("detail view should show a level published as text")
+	}
+
+	m.selectedModule = &model.ModuleItem{Module: model.Module{
+		ModuleName:   "Area Level Module",
+		OverallLevel: model.ParseSecurityLevel("Physical Security: 3\nSoftware Security: 1"),
+	}}
+	view := m.View()
+	if !strings.Contains(view, "Levels by Area:") || !strings.Contains(view, "Physical Security") {
+		t.Error("detail view should list per-area levels")
+	}
+	if !strings.Contains(view, "Levels 1-3") {
+		t.Error("detail view should summarize per-area levels in the badge")
+	}
+}
+
 func TestModel_View_DetailView_WithCaveat(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.width = 80
@@ -321,7 +725,7 @@ func TestModel_View_DetailView_WithCaveat(t *testing.T) {
 }
 
 func TestModel_View_DetailView_WithAlgoDetails(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.width = 80
@@ -345,7 +749,7 @@ func TestModel_View_DetailView_WithAlgoDetails(t *testing.T) {
 }
 
 func TestModel_View_DetailView_NilModule(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 	m.view = ViewDetail
 	m.selectedModule = nil
@@ -359,7 +763,7 @@ func TestModel_View_DetailView_NilModule(t *testing.T) {
 }
 
 func TestModel_Update_CtrlC_Quits(t *testing.T) {
-	m := NewModel()
+	m := newTestModel()
 	m.loading = false
 
 	msg := tea.KeyMsg{Type: tea.KeyCtrlC}
@@ -370,25 +774,69 @@ func TestModel_Update_CtrlC_Quits(t *testing.T) {
 	}
 }
 
-func TestBuildAlgorithmContent(t *testing.T) {
-	algorithms := []string{"AES-128", "SHA-256", "RSA-2048"}
-	content := buildAlgorithmContent(algorithms)
+func TestModel_SummarySource_FetchesDetails(t *testing.T) {
+	src := summarySource{api.NewMemorySource([]model.Module{
+		{CertificateNumber: "4282", ModuleName: "Full Record", Lab: "ACME LAB", Status: model.StatusActive},
+	}, api.MetadataJSON{})}
+	m := NewModel(src)
+	m.width = 80
+	m.height = 24
 
-	for _, algo := range algorithms {
-		if !strings.Contains(content, algo) {
-			t.Errorf("content should contain %s", algo)
-		}
+	summary := []list.Item{model.ModuleItem{Module: model.Module{CertificateNumber: "4282", ModuleName: "Summary"}}}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: summary})
+	m = newModel.(Model)
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if cmd == nil || !m.detailLoading {
+		t.Fatal("expected Enter to fetch details from a summary-only source")
+	}
+	if !strings.Contains(m.View(), "Loading certificate details") {
+		t.Error("expected detail view to show that details are loading")
+	}
+
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if m.detailLoading {
+		t.Error("expected detailLoading to be cleared")
+	}
+	if m.selectedModule.Lab != "ACME LAB" {
+		t.Errorf("selected module Lab = %q, want the fetched record", m.selectedModule.Lab)
+	}
+
+	// Reopening uses the fetched record without another request
+	m.view = ViewList
+	newModel, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = newModel.(Model)
+	if cmd != nil || m.selectedModule.Lab != "ACME LAB" {
+		t.Error("expected reopened module to use the already fetched details")
 	}
 }
 
-func TestBuildAlgorithmContent_Empty(t *testing.T) {
-	content := buildAlgorithmContent([]string{})
-	if content != "" {
-		t.Error("empty algorithms should produce empty content")
+func TestModel_FullSource_NoDetailFetch(t *testing.T) {
+	m := newTestModel()
+	m.width = 80
+	m.height = 24
+
+	items := []list.Item{model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "Module"}}}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: items})
+	m = newModel.(Model)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("expected no detail fetch when the list already has full records")
 	}
 }
 
 // Helper types for testing
+
+// summarySource is a MemorySource whose list is treated as summary-only
+type summarySource struct {
+	*api.MemorySource
+}
+
+func (summarySource) SummaryOnly() bool { return true }
+
 type testError struct{}
 
 func (e *testError) Error() string {
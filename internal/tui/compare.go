@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/ui"
+)
+
+// maxCompare is the number of modules that can be marked for comparison.
+// Marking another unmarks the oldest.
+const maxCompare = 3
+
+// compareLabelWidth matches the width of DetailLabelStyle
+const compareLabelWidth = 18
+
+// toggleMark marks or unmarks the selected module for comparison
+func (m *Model) toggleMark() {
+	item, ok := m.list.SelectedItem().(model.ModuleItem)
+	if !ok {
+		return
+	}
+	key := item.Module.Key()
+	if m.marked[key] {
+		for i, k := range m.compare {
+			if k == key {
+				m.compare = append(m.compare[:i:i], m.compare[i+1:]...)
+				break
+			}
+		}
+		delete(m.marked, key)
+		return
+	}
+	if len(m.compare) == maxCompare {
+		delete(m.marked, m.compare[0])
+		m.compare = m.compare[1:]
+	}
+	m.compare = append(m.compare, key)
+	m.marked[key] = true
+}
+
+// comparedModules returns the current records of the marked modules, oldest
+// mark first, skipping any that are no longer in the loaded data
+func (m Model) comparedModules() []model.Module {
+	byKey := make(map[string]model.Module, len(m.compare))
+	for _, item := range m.allModules {
+		if mi, ok := item.(model.ModuleItem); ok && m.marked[mi.Key()] {
+			byKey[mi.Key()] = mi.Module
+		}
+	}
+
+	var modules []model.Module
+	for _, key := range m.compare {
+		mod, ok := byKey[key]
+		if !ok {
+			continue
+		}
+		// Prefer full records fetched from a summary-only source
+		if full, ok := m.details[mod.CertificateNumber]; ok && mod.CertificateNumber != "" {
+			mod = full
+		}
+		modules = append(modules, mod)
+	}
+	return modules
+}
+
+// openCompare shows the marked modules side by side
+func (m Model) openCompare() Model {
+	if len(m.compare) < 2 {
+		return m
+	}
+	m.compareViewport = viewport.New(m.width-4, m.height-6)
+	m.view = ViewCompare
+	return m.refreshCompare()
+}
+
+// refreshCompare re-renders the compare view from the loaded data at the
+// current window size, keeping the scroll position
+func (m Model) refreshCompare() Model {
+	offset := m.compareViewport.YOffset
+	m.compareViewport.Width = m.width - 4
+	m.compareViewport.Height = m.height - 6
+
+	modules := m.comparedModules()
+	if len(modules) == 0 {
+		m.compareViewport.SetContent(HelpStyle.UnsetMarginTop().Render("The marked modules are no longer in the loaded data."))
+	} else {
+		m.compareViewport.SetContent(buildCompareContent(modules, m.width-4))
+	}
+	m.compareViewport.SetYOffset(offset)
+	return m
+}
+
+// buildCompareContent renders modules in columns with their detail fields
+// aligned. Differing caveats, levels and sunset dates are highlighted, and
+// the algorithms are split into those common to all modules and those only
+// some implement.
+func buildCompareContent(modules []model.Module, width int) string {
+	colWidth := max(20, (width-compareLabelWidth)/len(modules))
+	cell := lipgloss.NewStyle().Width(colWidth).PaddingRight(2)
+
+	var rows []string
+	row := func(label string, cells []string) {
+		rendered := []string{DetailLabelStyle.Render(label)}
+		for _, c := range cells {
+			rendered = append(rendered, cell.Render(c))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, rendered...))
+	}
+	values := func(highlight bool, vs []string) []string {
+		if !highlight || allEqual(vs) {
+			return vs
+		}
+		out := make([]string, len(vs))
+		for i, v := range vs {
+			if v == "" {
+				v = "—"
+			}
+			out[i] = CompareDiffStyle.Render(v)
+		}
+		return out
+	}
+
+	names := make([]string, len(modules))
+	statuses := make([]string, len(modules))
+	caveats := make([]string, len(modules))
+	fields := make([][]ui.DetailField, len(modules))
+	for i, mod := range modules {
+		names[i] = DetailTitleStyle.UnsetMarginBottom().Render(mod.ModuleName)
+		statuses[i] = StatusBadge(mod.Status)
+		caveats[i] = mod.Caveat
+		fields[i] = detailFields(mod)
+	}
+	row("", names)
+	row("", statuses)
+	rows = append(rows, "")
+
+	if !allEmpty(caveats) {
+		row("Caveat:", values(true, caveats))
+	}
+	for f := range fields[0] {
+		vs := make([]string, len(modules))
+		for i := range modules {
+			vs[i] = fields[i][f].Value
+		}
+		if allEmpty(vs) {
+			continue
+		}
+		label := fields[0][f].Label
+		if fields[0][f].IsURL {
+			for i := range vs {
+				vs[i] = DetailURLStyle.Render(vs[i])
+			}
+		}
+		row(label, values(fields[0][f].Highlight, vs))
+	}
+
+	common, only := diffAlgorithms(modules)
+	rows = append(rows, "")
+	if len(common) > 0 {
+		rows = append(rows, DetailLabelStyle.Render("Common Algorithms:"))
+		rows = append(rows, lipgloss.NewStyle().Width(width).Render(strings.Join(common, ", ")))
+	} else {
+		row("Common Algorithms:", []string{"None"})
+	}
+	rows = append(rows, "")
+	onlyCells := make([]string, len(modules))
+	for i, names := range only {
+		if len(names) == 0 {
+			onlyCells[i] = HelpStyle.UnsetMarginTop().Render("—")
+			continue
+		}
+		onlyCells[i] = CompareDiffStyle.Render(strings.Join(names, "\n"))
+	}
+	row("Only Here:", onlyCells)
+
+	return strings.Join(rows, "\n")
+}
+
+// diffAlgorithms splits the normalized algorithms of modules into those
+// every module implements and, per module, those the others lack
+func diffAlgorithms(modules []model.Module) (common []string, only [][]string) {
+	count := make(map[string]int)
+	sets := make([]map[string]bool, len(modules))
+	for i, mod := range modules {
+		sets[i] = make(map[string]bool)
+		for _, name := range mod.AlgorithmNames() {
+			if !sets[i][name] {
+				sets[i][name] = true
+				count[name]++
+			}
+		}
+	}
+	for name, n := range count {
+		if n == len(modules) {
+			common = append(common, name)
+		}
+	}
+	sort.Strings(common)
+
+	only = make([][]string, len(modules))
+	for i, set := range sets {
+		for name := range set {
+			if count[name] < len(modules) {
+				only[i] = append(only[i], name)
+			}
+		}
+		sort.Strings(only[i])
+	}
+	return common, only
+}
+
+func allEqual(vs []string) bool {
+	for _, v := range vs[1:] {
+		if v != vs[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func allEmpty(vs []string) bool {
+	for _, v := range vs {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Model) renderCompareView() string {
+	title := TitleStyle.Render(fmt.Sprintf("Comparing %d Modules", len(m.compare)))
+	help := HelpStyle.Render("j/k: scroll • highlighted: caveats, levels and sunset dates that differ • esc: back")
+	return AppStyle.Render(title + "\n\n" + m.compareViewport.View() + "\n" + help)
+}
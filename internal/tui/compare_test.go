@@ -0,0 +1,166 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func compareTestItems() []list.Item {
+	return testItems(
+		model.Module{CertificateNumber: "4282", ModuleName: "OpenSSL FIPS Provider", VendorName: "OpenSSL",
+			OverallLevel: model.NewSecurityLevel(1), SunsetDate: time.Date(2029, 7, 11, 0, 0, 0, 0, time.UTC),
+			Algorithms: []string{"AES", "SHA-256", "ML-KEM"}},
+		model.Module{CertificateNumber: "4985", ModuleName: "OpenSSL FIPS Provider 3.1", VendorName: "OpenSSL",
+			OverallLevel: model.NewSecurityLevel(1), SunsetDate: time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC),
+			Caveat: "When installed on the tested platforms", Algorithms: []string{"AES", "SHA2-256", "RSA"}},
+		model.Module{CertificateNumber: "100", ModuleName: "Third"},
+		model.Module{CertificateNumber: "101", ModuleName: "Fourth"},
+	)
+}
+
+func TestModel_MarkModules(t *testing.T) {
+	m := loadedModel(t, compareTestItems())
+
+	// Mark every module; only the last maxCompare stay marked
+	for range compareTestItems() {
+		newModel, _ := m.Update(keyMsg("m"))
+		newModel, _ = newModel.(Model).Update(keyMsg("down"))
+		m = newModel.(Model)
+	}
+	if !reflect.DeepEqual(m.compare, []string{"4985", "100", "101"}) {
+		t.Errorf("marked = %v, want the last %d", m.compare, maxCompare)
+	}
+	if m.marked["4282"] || !m.marked["101"] {
+		t.Errorf("marked keys = %v, want them in sync with the marked modules", m.marked)
+	}
+	if !strings.Contains(m.renderStatusLine(), "3 marked") {
+		t.Error("status line should show the marked count")
+	}
+
+	// Marking a marked module unmarks it
+	newModel, _ := m.Update(keyMsg("up"))
+	newModel, _ = newModel.(Model).Update(keyMsg("m"))
+	m = newModel.(Model)
+	if len(m.compare) != 2 || m.marked["100"] {
+		t.Errorf("expected m to unmark the selected module, marked %v", m.marked)
+	}
+}
+
+func TestModel_CompareView(t *testing.T) {
+	m := loadedModel(t, compareTestItems())
+
+	// One module is not enough to compare
+	newModel, _ := m.Update(keyMsg("m"))
+	newModel, _ = newModel.(Model).Update(keyMsg("c"))
+	m = newModel.(Model)
+	if m.view != ViewList {
+		t.Fatalf("view = %v, want ViewList with one module marked", m.view)
+	}
+
+	newModel, _ = m.Update(keyMsg("down"))
+	newModel, _ = newModel.(Model).Update(keyMsg("m"))
+	newModel, _ = newModel.(Model).Update(keyMsg("c"))
+	m = newModel.(Model)
+	if m.view != ViewCompare {
+		t.Fatalf("view = %v, want ViewCompare", m.view)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Comparing 2 Modules", "OpenSSL FIPS Provider 3.1", "Caveat:", "Sunset Date:", "Common Algorithms:", "ML-KEM", "RSA"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("compare view missing %q", want)
+		}
+	}
+
+	newModel, _ = m.Update(keyMsg("esc"))
+	if newModel.(Model).view != ViewList {
+		t.Error("expected esc to return to the list")
+	}
+}
+
+func TestModel_CompareView_Refresh(t *testing.T) {
+	m := loadedModel(t, compareTestItems())
+	for _, k := range []string{"m", "down", "m", "c"} {
+		newModel, _ := m.Update(keyMsg(k))
+		m = newModel.(Model)
+	}
+	if m.view != ViewCompare {
+		t.Fatalf("view = %v, want ViewCompare", m.view)
+	}
+
+	// A background refresh updates the compared records
+	items := compareTestItems()
+	mi := items[0].(model.ModuleItem)
+	mi.OverallLevel = model.NewSecurityLevel(2)
+	mi.Caveat = "Refreshed caveat"
+	items[0] = mi
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: items})
+	m = newModel.(Model)
+	if view := m.View(); !strings.Contains(view, "Refreshed caveat") || !strings.Contains(view, "Level 2") {
+		t.Errorf("compare view should show the refreshed records:\n%s", view)
+	}
+
+	// Resizing keeps the scroll position
+	newModel, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 16})
+	m = newModel.(Model)
+	m.compareViewport.SetYOffset(3)
+	newModel, _ = m.Update(tea.WindowSizeMsg{Width: 110, Height: 16})
+	m = newModel.(Model)
+	if m.compareViewport.YOffset != 3 {
+		t.Errorf("YOffset = %d after resize, want 3", m.compareViewport.YOffset)
+	}
+}
+
+func TestDiffAlgorithms(t *testing.T) {
+	modules := []model.Module{
+		{Algorithms: []string{"AES", "SHA-256", "ML-KEM"}},
+		{Algorithms: []string{"AES", "SHA2-256", "RSA"}},
+	}
+	common, only := diffAlgorithms(modules)
+	if !reflect.DeepEqual(common, []string{"AES", "SHA2-256"}) {
+		t.Errorf("common = %v, want normalized names shared by both", common)
+	}
+	if !reflect.DeepEqual(only, [][]string{{"ML-KEM"}, {"RSA"}}) {
+		t.Errorf("only = %v", only)
+	}
+}
+
+func TestBuildCompareContent_AlignsFields(t *testing.T) {
+	modules := []model.Module{
+		{ModuleName: "A", VendorName: "Vendor A", Lab: "Lab", Algorithms: []string{"AES"}},
+		{ModuleName: "B", VendorName: "Vendor B", Caveat: "Only B", Algorithms: []string{"RSA"}},
+	}
+	lines := strings.Split(buildCompareContent(modules, 100), "\n")
+
+	find := func(label string) string {
+		for _, line := range lines {
+			if strings.HasPrefix(line, label) {
+				return line
+			}
+		}
+		t.Fatalf("no %q row", label)
+		return ""
+	}
+	if row := find("Vendor:"); strings.Index(row, "Vendor A") >= strings.Index(row, "Vendor B") {
+		t.Errorf("vendor row = %q, want the values side by side", row)
+	}
+	// A missing caveat is shown as a dash when the caveats differ
+	if row := find("Caveat:"); !strings.Contains(row, "—") || !strings.Contains(row, "Only B") {
+		t.Errorf("caveat row = %q", row)
+	}
+	// A field only one module has is still aligned under its column
+	if row := find("Lab:"); !strings.Contains(row, "Lab") {
+		t.Errorf("lab row = %q", row)
+	}
+	for _, line := range lines {
+		if strings.HasPrefix(line, "Sunset Date:") {
+			t.Error("rows empty for every module should be skipped")
+		}
+	}
+}
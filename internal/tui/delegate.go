@@ -8,12 +8,15 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/watchlist"
 )
 
 // ModuleDelegate is a custom delegate for rendering module items in the list
 type ModuleDelegate struct {
 	ShowDescription bool
 	Styles          ModuleDelegateStyles
+	Marked          map[string]bool      // Module.Key of modules marked for comparison
+	Watchlist       *watchlist.Watchlist // Watched modules, badged with a star
 }
 
 // ModuleDelegateStyles contains styles for the module delegate
@@ -105,6 +108,15 @@ func (d ModuleDelegate) Render(w io.Writer, m list.Model, index int, item list.I
 		title = moduleItem.Title()
 	}
 	fmt.Fprint(w, titleStyle.Render(title))
+	if d.Watchlist.Contains(moduleItem.Module.Key()) {
+		fmt.Fprint(w, "  "+WatchedBadge.Render("★"))
+	}
+	if d.Marked[moduleItem.Module.Key()] {
+		fmt.Fprint(w, "  "+MarkedBadge.Render("compare"))
+	}
+	if badge := SunsetBadge(moduleItem.Module, now()); badge != "" {
+		fmt.Fprint(w, "  "+badge)
+	}
 
 	if d.ShowDescription {
 		fmt.Fprint(w, "\n")
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 
@@ -164,3 +165,25 @@ func TestModuleDelegate_Render_WithoutDescription(t *testing.T) {
 		t.Error("Render() should not include newline when ShowDescription is false")
 	}
 }
+
+func TestModuleDelegate_Render_SunsetBadge(t *testing.T) {
+	pinNow(t, time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	d := NewModuleDelegate()
+
+	item := model.ModuleItem{
+		Module: model.Module{
+			CertificateNumber: "4000",
+			ModuleName:        "Expiring Module",
+			Status:            model.StatusActive,
+			SunsetDate:        time.Date(2026, 11, 16, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	l := list.New([]list.Item{item}, d, 80, 24)
+
+	var buf bytes.Buffer
+	d.Render(&buf, l, 0, item)
+
+	if !strings.Contains(buf.String(), "Sunset in 30 days") {
+		t.Errorf("Render() should include the sunset countdown, got %q", buf.String())
+	}
+}
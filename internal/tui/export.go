@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/export"
+	"github.com/ethanolivertroy/cmvp-tui/internal/fsutil"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// defaultExportPath is offered when the export prompt first opens
+const defaultExportPath = "cmvp-modules.csv"
+
+// toastDuration is how long an export confirmation stays on screen
+const toastDuration = 4 * time.Second
+
+// ExportedMsg is sent when the visible modules have been written to a file
+type ExportedMsg struct {
+	Path  string
+	Count int
+	Err   error
+}
+
+// toastExpiredMsg clears the toast it was scheduled for, unless a newer
+// toast replaced it
+type toastExpiredMsg struct {
+	id int
+}
+
+// openExportPrompt asks for the file to export the visible modules to,
+// offering the path used last
+func (m Model) openExportPrompt() (Model, tea.Cmd) {
+	input := textinput.New()
+	input.Prompt = "Export to (.csv, .json, .md): "
+	input.PromptStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+	input.Width = max(m.width-40, 20)
+	path := m.exportPath
+	if path == "" {
+		path = defaultExportPath
+	}
+	input.SetValue(path)
+	input.CursorEnd()
+	m.exportPrompt = input
+	m.exporting = true
+	m.exportConfirm = ""
+	return m, m.exportPrompt.Focus()
+}
+
+// updateExportPrompt handles a key while the export prompt is open. Enter
+// on a path that already exists asks for confirmation, and a second enter
+// on the same path overwrites the file.
+func (m Model) updateExportPrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		path := strings.TrimSpace(m.exportPrompt.Value())
+		if path == "" {
+			return m, nil
+		}
+		overwrite := m.exportConfirm == path
+		if _, err := os.Stat(expandHome(path)); err == nil && !overwrite {
+			m.exportConfirm = path
+			return m, nil
+		}
+		m.exporting = false
+		m.exportConfirm = ""
+		m.exportPath = path
+		return m, exportModules(path, m.visibleModules(), overwrite)
+	case "esc", "ctrl+c":
+		m.exporting = false
+		m.exportConfirm = ""
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.exportPrompt, cmd = m.exportPrompt.Update(msg)
+	return m, cmd
+}
+
+// visibleModules returns the modules the list currently shows, in order,
+// preferring full records fetched for summary-only sources
+func (m Model) visibleModules() []model.Module {
+	var modules []model.Module
+	for _, item := range m.list.VisibleItems() {
+		mi, ok := item.(model.ModuleItem)
+		if !ok {
+			continue
+		}
+		if full, ok := m.details[mi.CertificateNumber]; ok && mi.CertificateNumber != "" {
+			modules = append(modules, full)
+		} else {
+			modules = append(modules, mi.Module)
+		}
+	}
+	return modules
+}
+
+// exportModules writes modules to path in the format its extension names.
+// An existing file is only replaced if overwrite is set.
+func exportModules(path string, modules []model.Module, overwrite bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := ExportedMsg{Path: path, Count: len(modules)}
+		msg.Err = writeExport(expandHome(path), modules, overwrite)
+		return msg
+	}
+}
+
+func writeExport(path string, modules []model.Module, overwrite bool) error {
+	if len(modules) == 0 {
+		return fmt.Errorf("no modules are listed")
+	}
+	format, err := export.FormatForPath(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := export.Write(&buf, format, modules); err != nil {
+		return err
+	}
+
+	// Write through a temp file so a failed write never leaves half an
+	// export, and refuse to replace a file created since the prompt closed
+	if overwrite {
+		err = fsutil.WriteFileAtomic(path, buf.Bytes(), 0o644)
+	} else {
+		err = fsutil.CreateFileAtomic(path, buf.Bytes(), 0o644)
+	}
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("file exists: %s", path)
+	}
+	return err
+}
+
+// renderExportPrompt renders the export prompt, warning when enter would
+// overwrite an existing file
+func (m Model) renderExportPrompt() string {
+	line := StatusBarStyle.Render(m.exportPrompt.View())
+	if m.exportConfirm != "" && m.exportConfirm == strings.TrimSpace(m.exportPrompt.Value()) {
+		line += ToastErrorStyle.Render("File exists; enter again to overwrite it")
+	}
+	return line
+}
+
+// expandHome replaces a leading ~/ with the user's home directory
+func expandHome(path string) string {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, rest)
+}
+
+// showToast displays text in the status line for toastDuration
+func (m Model) showToast(text string, isErr bool) (Model, tea.Cmd) {
+	m.toastID++
+	m.toast = text
+	m.toastErr = isErr
+	id := m.toastID
+	return m, tea.Tick(toastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{id: id}
+	})
+}
+
+// renderToast renders the current toast, if any
+func (m Model) renderToast() string {
+	switch {
+	case m.toast == "":
+		return ""
+	case m.toastErr:
+		return ToastErrorStyle.Render("✗ " + m.toast)
+	default:
+		return ToastStyle.Render("✓ " + m.toast)
+	}
+}
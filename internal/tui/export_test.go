@@ -0,0 +1,169 @@
+package tui
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// exportTo opens the export prompt, enters path and runs the export
+func exportTo(t *testing.T, m Model, path string) Model {
+	t.Helper()
+	newModel, _ := m.Update(keyMsg("x"))
+	m = newModel.(Model)
+	if !m.exporting {
+		t.Fatal("x should open the export prompt")
+	}
+	m.exportPrompt.SetValue(path)
+	newModel, cmd := m.Update(keyMsg("enter"))
+	m = newModel.(Model)
+	if m.exporting || cmd == nil {
+		t.Fatal("enter should close the prompt and start the export")
+	}
+	newModel, _ = m.Update(cmd())
+	return newModel.(Model)
+}
+
+func TestModel_Export(t *testing.T) {
+	m := loadedModel(t, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "OpenSSL Provider", VendorName: "OpenSSL",
+			Standard: "FIPS 140-3", OverallLevel: model.NewSecurityLevel(1), Caveat: "Approved mode only"}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "2", ModuleName: "Acme HSM", VendorName: "Acme"}},
+	})
+	m.list.SetFilterText("vendor:openssl")
+
+	path := filepath.Join(t.TempDir(), "modules.csv")
+	m = exportTo(t, m, path)
+	if !strings.Contains(m.View(), "Exported 1 module to "+path) {
+		t.Errorf("view missing export toast:\n%s", m.View())
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][0] != "1" {
+		t.Fatalf("exported rows = %v, want only the filtered module", rows)
+	}
+	for _, want := range []string{"FIPS 140-3", "Level 1", "Approved mode only"} {
+		if !strings.Contains(strings.Join(rows[1], ","), want) {
+			t.Errorf("exported row missing %q: %v", want, rows[1])
+		}
+	}
+
+	// The toast clears itself
+	newModel, _ := m.Update(toastExpiredMsg{id: m.toastID})
+	m = newModel.(Model)
+	if strings.Contains(m.View(), "Exported") {
+		t.Error("toast should clear when it expires")
+	}
+}
+
+func TestModel_ExportFormats(t *testing.T) {
+	m := loadedModel(t, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "42", ModuleName: "Provider"}},
+	})
+	dir := t.TempDir()
+
+	for name, want := range map[string]string{
+		"out.json": `"certificate_number": "42"`,
+		"out.md":   "| 42 |  | Provider |",
+	} {
+		m = exportTo(t, m, filepath.Join(dir, name))
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s missing %q:\n%s", name, want, data)
+		}
+	}
+}
+
+func TestModel_ExportErrors(t *testing.T) {
+	m := loadedModel(t, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "Provider"}},
+	})
+
+	m = exportTo(t, m, filepath.Join(t.TempDir(), "out.xlsx"))
+	if !m.toastErr || !strings.Contains(m.View(), "Export failed") {
+		t.Errorf("expected an export failure toast, got %q", m.toast)
+	}
+
+	// Esc closes the prompt without exporting
+	newModel, _ := m.Update(keyMsg("x"))
+	m = newModel.(Model)
+	newModel, cmd := m.Update(keyMsg("esc"))
+	m = newModel.(Model)
+	if m.exporting || cmd != nil {
+		t.Error("esc should cancel the export")
+	}
+	if m.view != ViewList {
+		t.Errorf("view = %v, want ViewList", m.view)
+	}
+
+	// Keys go to the prompt, not the list
+	newModel, _ = m.Update(keyMsg("x"))
+	m = newModel.(Model)
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = newModel.(Model)
+	if !m.exporting || !strings.HasSuffix(m.exportPrompt.Value(), "q") {
+		t.Errorf("prompt value = %q, want typed q appended", m.exportPrompt.Value())
+	}
+}
+
+func TestModel_ExportOverwrite(t *testing.T) {
+	m := loadedModel(t, testItems(model.Module{CertificateNumber: "1", ModuleName: "Provider"}))
+	path := filepath.Join(t.TempDir(), "out.csv")
+	if err := os.WriteFile(path, []byte("keep me"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first enter asks before replacing the file
+	newModel, _ := m.Update(keyMsg("x"))
+	m = newModel.(Model)
+	m.exportPrompt.SetValue(path)
+	newModel, cmd := m.Update(keyMsg("enter"))
+	m = newModel.(Model)
+	if !m.exporting || cmd != nil {
+		t.Fatal("enter on an existing file should keep the prompt open")
+	}
+	if !strings.Contains(m.View(), "File exists") {
+		t.Errorf("view missing the overwrite warning:\n%s", m.View())
+	}
+	if data, _ := os.ReadFile(path); string(data) != "keep me" {
+		t.Fatalf("file = %q, want it untouched", data)
+	}
+
+	// The second enter overwrites it
+	newModel, cmd = m.Update(keyMsg("enter"))
+	m = newModel.(Model)
+	if m.exporting || cmd == nil {
+		t.Fatal("a second enter should start the export")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if m.toastErr {
+		t.Fatalf("export failed: %s", m.toast)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "Provider") {
+		t.Errorf("file = %q, want the export", data)
+	}
+
+	// A file created after the prompt closed is never replaced silently
+	err := writeExport(path, []model.Module{{CertificateNumber: "1"}}, false)
+	if err == nil || !strings.Contains(err.Error(), "file exists") {
+		t.Errorf("writeExport error = %v, want file exists", err)
+	}
+}
@@ -0,0 +1,352 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/query"
+)
+
+// facetSidebarWidth is the width of the facet sidebar, including its border
+const facetSidebarWidth = 34
+
+// Sidebar keys, listed in the module list's help. F does not collide with
+// the list's own bindings, which include f for the next page.
+var (
+	facetSidebarKey = key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "facets"))
+	facetCheckKey   = key.NewBinding(key.WithKeys(" "), key.WithHelp("space", "check facet"))
+	facetBlurKey    = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "focus list"))
+)
+
+// facetHelpKeys returns the sidebar keys for the list's short help
+func facetHelpKeys() []key.Binding {
+	return []key.Binding{facetSidebarKey}
+}
+
+// facetFullHelpKeys returns the sidebar keys for the list's full help
+func facetFullHelpKeys() []key.Binding {
+	return []key.Binding{facetSidebarKey, facetCheckKey, facetBlurKey}
+}
+
+// statusTab narrows the module list to one validation status
+type statusTab int
+
+const (
+	tabAll statusTab = iota
+	tabActive
+	tabHistorical
+	tabInProcess
+	statusTabs // Number of tabs
+)
+
+func (t statusTab) String() string {
+	switch t {
+	case tabActive:
+		return model.StatusActive.String()
+	case tabHistorical:
+		return model.StatusHistorical.String()
+	case tabInProcess:
+		return model.StatusInProcess.String()
+	default:
+		return "All"
+	}
+}
+
+// matches reports whether mod belongs on the tab
+func (t statusTab) matches(mod *model.Module) bool {
+	switch t {
+	case tabActive:
+		return mod.Status == model.StatusActive
+	case tabHistorical:
+		return mod.Status == model.StatusHistorical
+	case tabInProcess:
+		return mod.Status == model.StatusInProcess
+	default:
+		return true
+	}
+}
+
+// facetField is a module field the sidebar can narrow the list by
+type facetField int
+
+const (
+	facetModuleType facetField = iota
+	facetEmbodiment
+	facetStandard
+	facetLevel
+	facetLab
+	facetFields // Number of facet fields
+)
+
+func (f facetField) String() string {
+	switch f {
+	case facetModuleType:
+		return "Module Type"
+	case facetEmbodiment:
+		return "Embodiment"
+	case facetStandard:
+		return "Standard"
+	case facetLevel:
+		return "Overall Level"
+	case facetLab:
+		return "Lab"
+	default:
+		return "Unknown"
+	}
+}
+
+// value returns the facet value of mod, or "" if it has none
+func (f facetField) value(mod *model.Module) string {
+	switch f {
+	case facetModuleType:
+		return mod.ModuleType
+	case facetEmbodiment:
+		return mod.Embodiment
+	case facetStandard:
+		return mod.Standard
+	case facetLevel:
+		if mod.OverallLevel.IsZero() {
+			return ""
+		}
+		return mod.OverallLevel.String()
+	case facetLab:
+		return mod.Lab
+	default:
+		return ""
+	}
+}
+
+// facets holds the values checked in the sidebar. Checked values of one
+// field are alternatives; checked fields must all match.
+type facets map[facetField]map[string]bool
+
+// matches reports whether mod has a checked value for every field with
+// checked values, ignoring the field skip
+func (fs facets) matches(mod *model.Module, skip facetField) bool {
+	for field, values := range fs {
+		if field == skip || len(values) == 0 {
+			continue
+		}
+		if !values[field.value(mod)] {
+			return false
+		}
+	}
+	return true
+}
+
+// facetValue is a row in the facet sidebar
+type facetValue struct {
+	field   facetField
+	value   string
+	count   int // Listed modules that would match if the value were checked
+	checked bool
+}
+
+// facetCounts caches the tab counts and sidebar rows, which scan every
+// module, for the listed items and filter they were counted against
+type facetCounts struct {
+	valid  bool
+	gen    int    // Model.itemsGen when counted
+	filter string // Filter text when counted, "" if unfiltered
+	tabs   [statusTabs]int
+	values []facetValue
+}
+
+// refreshCounts recounts the tabs and facet values if the listed items or
+// the filter changed since they were last counted
+func (m *Model) refreshCounts() {
+	filter := ""
+	if m.list.FilterState() != list.Unfiltered {
+		filter = m.list.FilterValue()
+	}
+	if m.counts.valid && m.counts.gen == m.itemsGen && m.counts.filter == filter {
+		return
+	}
+	m.counts = facetCounts{valid: true, gen: m.itemsGen, filter: filter, tabs: m.tabCounts(), values: m.facetValues()}
+	m.facetCursor = max(0, min(m.facetCursor, len(m.counts.values)-1))
+}
+
+// textQuery returns the query typed into the list filter, or nil if there
+// is none or it does not parse
+func (m Model) textQuery() *query.Query {
+	if m.list.FilterState() == list.Unfiltered {
+		return nil
+	}
+	q, err := query.Parse(m.list.FilterValue())
+	if err != nil {
+		return nil
+	}
+	return q
+}
+
+// narrow keeps the items on the current tab that match the checked facets
+func (m Model) narrow(items []list.Item) []list.Item {
+	if m.tab == tabAll && len(m.facets) == 0 {
+		return items
+	}
+	narrowed := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		mi, ok := item.(model.ModuleItem)
+		if ok && m.tab.matches(&mi.Module) && m.facets.matches(&mi.Module, facetFields) {
+			narrowed = append(narrowed, item)
+		}
+	}
+	return narrowed
+}
+
+// tabCounts returns how many modules matching the facets and text filter
+// each tab lists
+func (m Model) tabCounts() [statusTabs]int {
+	var counts [statusTabs]int
+	q := m.textQuery()
+	for _, item := range m.allModules {
+		mi, ok := item.(model.ModuleItem)
+		if !ok || !m.facets.matches(&mi.Module, facetFields) || (q != nil && !q.Match(&mi.Module)) {
+			continue
+		}
+		for t := range statusTabs {
+			if t.matches(&mi.Module) {
+				counts[t]++
+			}
+		}
+	}
+	return counts
+}
+
+// facetValues lists every value of each facet field with the number of
+// modules on the current tab that match it, the text filter and the other
+// fields' checked values
+func (m Model) facetValues() []facetValue {
+	counts := make(map[facetField]map[string]int)
+	for f := range facetFields {
+		counts[f] = make(map[string]int)
+	}
+
+	q := m.textQuery()
+	for _, item := range m.allModules {
+		mi, ok := item.(model.ModuleItem)
+		if !ok {
+			continue
+		}
+		mod := &mi.Module
+		live := m.tab.matches(mod) && (q == nil || q.Match(mod))
+		for f := range facetFields {
+			v := f.value(mod)
+			if v == "" {
+				continue
+			}
+			if _, seen := counts[f][v]; !seen {
+				counts[f][v] = 0
+			}
+			if live && m.facets.matches(mod, f) {
+				counts[f][v]++
+			}
+		}
+	}
+
+	var values []facetValue
+	for f := range facetFields {
+		names := make([]string, 0, len(counts[f]))
+		for v := range counts[f] {
+			names = append(names, v)
+		}
+		sort.Strings(names)
+		for _, v := range names {
+			values = append(values, facetValue{field: f, value: v, count: counts[f][v], checked: m.facets[f][v]})
+		}
+	}
+	return values
+}
+
+// toggleFacet checks or unchecks the value under the sidebar cursor
+func (m *Model) toggleFacet() {
+	values := m.counts.values
+	if m.facetCursor >= len(values) {
+		return
+	}
+	v := values[m.facetCursor]
+	if m.facets == nil {
+		m.facets = make(facets)
+	}
+	if m.facets[v.field] == nil {
+		m.facets[v.field] = make(map[string]bool)
+	}
+	if v.checked {
+		delete(m.facets[v.field], v.value)
+		if len(m.facets[v.field]) == 0 {
+			delete(m.facets, v.field)
+		}
+	} else {
+		m.facets[v.field][v.value] = true
+	}
+}
+
+// moveFacetCursor moves the sidebar cursor by delta rows
+func (m *Model) moveFacetCursor(delta int) {
+	n := len(m.counts.values)
+	m.facetCursor = max(0, min(m.facetCursor+delta, n-1))
+}
+
+func (m Model) renderTabs() string {
+	counts := m.counts.tabs
+	tabs := make([]string, statusTabs)
+	for t := range statusTabs {
+		label := fmt.Sprintf("%s (%d)", t, counts[t])
+		if t == m.tab {
+			tabs[t] = ActiveTabStyle.Render(label)
+		} else {
+			tabs[t] = InactiveTabStyle.Render(label)
+		}
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// renderFacetSidebar renders the facet checkboxes, scrolled to keep the
+// cursor in view
+func (m Model) renderFacetSidebar(height int) string {
+	width := facetSidebarWidth - 4 // Border and padding
+	var rows []string
+	cursorRow := 0
+	var field facetField = -1
+	for i, v := range m.counts.values {
+		if v.field != field {
+			field = v.field
+			rows = append(rows, FacetHeaderStyle.Render(field.String()))
+		}
+		box := "[ ]"
+		if v.checked {
+			box = "[x]"
+		}
+		count := fmt.Sprintf("%d", v.count)
+		label := truncate(v.value, width-len(box)-len(count)-2)
+		row := fmt.Sprintf("%s %s%s%s", box, label, strings.Repeat(" ", max(1, width-len(box)-1-lipgloss.Width(label)-len(count))), count)
+		switch {
+		case i == m.facetCursor:
+			row = FacetCursorStyle.Render(row)
+			cursorRow = len(rows)
+		case v.count == 0 && !v.checked:
+			row = HelpStyle.UnsetMarginTop().Render(row)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		rows = append(rows, HelpStyle.UnsetMarginTop().Render("No facets"))
+	}
+
+	height = max(1, height-2) // Border
+	start := 0
+	if len(rows) > height {
+		start = min(max(0, cursorRow-height/2), len(rows)-height)
+	}
+	rows = rows[start:min(len(rows), start+height)]
+	style := FacetSidebarStyle
+	if m.sidebarFocused {
+		style = style.BorderForeground(PrimaryColor)
+	}
+	return style.Width(facetSidebarWidth - 2).Height(height).Render(strings.Join(rows, "\n"))
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func facetTestItems() []list.Item {
+	return testItems(
+		model.Module{ModuleName: "Soft 3", VendorName: "Acme", ModuleType: "Software", Standard: "FIPS 140-3",
+			Lab: "Lab A", OverallLevel: model.NewSecurityLevel(1), Status: model.StatusActive},
+		model.Module{ModuleName: "Hard 3", VendorName: "Acme", ModuleType: "Hardware", Standard: "FIPS 140-3",
+			Lab: "Lab B", OverallLevel: model.NewSecurityLevel(3), Status: model.StatusActive},
+		model.Module{ModuleName: "Soft 2", VendorName: "Other", ModuleType: "Software", Standard: "FIPS 140-2",
+			Lab: "Lab A", OverallLevel: model.NewSecurityLevel(1), Status: model.StatusHistorical},
+		model.Module{ModuleName: "Queued", VendorName: "Acme", Standard: "FIPS 140-3", Status: model.StatusInProcess},
+	)
+}
+
+func TestModel_StatusTabs(t *testing.T) {
+	m := loadedModel(t, facetTestItems())
+
+	if tabs := m.renderTabs(); !strings.Contains(tabs, "All (4)") || !strings.Contains(tabs, "Active (2)") ||
+		!strings.Contains(tabs, "Historical (1)") || !strings.Contains(tabs, "In Process (1)") {
+		t.Errorf("tabs = %q, want counts per status", tabs)
+	}
+
+	newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(Model)
+	if m.tab != tabActive || len(m.list.Items()) != 2 {
+		t.Errorf("tab = %s with %d items, want Active with 2", m.tab, len(m.list.Items()))
+	}
+
+	newModel, _ = m.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	newModel, _ = newModel.(Model).Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	m = newModel.(Model)
+	if m.tab != tabInProcess || len(m.list.Items()) != 1 {
+		t.Errorf("tab = %s with %d items, want In Process with 1", m.tab, len(m.list.Items()))
+	}
+
+	// Tab counts follow the text filter
+	m.list.SetFilterText("vendor:other")
+	m.refreshCounts()
+	if tabs := m.renderTabs(); !strings.Contains(tabs, "All (1)") || !strings.Contains(tabs, "Active (0)") {
+		t.Errorf("tabs = %q, want counts narrowed by the filter", tabs)
+	}
+}
+
+func TestModel_FacetValues(t *testing.T) {
+	m := loadedModel(t, facetTestItems())
+	m.facets = facets{facetModuleType: {"Software": true}}
+
+	counts := make(map[string]int)
+	for _, v := range m.facetValues() {
+		counts[v.field.String()+"/"+v.value] = v.count
+	}
+	want := map[string]int{
+		// Counts for the checked field ignore its own selection
+		"Module Type/Software": 2,
+		"Module Type/Hardware": 1,
+		// Other fields only count Software modules
+		"Standard/FIPS 140-3":   1,
+		"Standard/FIPS 140-2":   1,
+		"Overall Level/Level 1": 2,
+		"Overall Level/Level 3": 0,
+		"Lab/Lab B":             0,
+	}
+	for k, n := range want {
+		if counts[k] != n {
+			t.Errorf("count %s = %d, want %d", k, counts[k], n)
+		}
+	}
+	if _, ok := counts["Embodiment/"]; ok {
+		t.Error("empty values should not be listed")
+	}
+}
+
+func TestModel_FacetSidebar(t *testing.T) {
+	m := loadedModel(t, facetTestItems())
+
+	newModel, _ := m.Update(keyMsg("F"))
+	m = newModel.(Model)
+	if !m.showFacets || !m.sidebarFocused || m.list.Width() != m.width-4-facetSidebarWidth {
+		t.Fatalf("expected F to open the sidebar focused and narrow the list")
+	}
+
+	// The first row is Module Type: Hardware
+	newModel, _ = m.Update(keyMsg(" "))
+	m = newModel.(Model)
+	if got := len(m.list.Items()); got != 1 {
+		t.Fatalf("got %d items, want only the hardware module", got)
+	}
+	if view := m.View(); !strings.Contains(view, "[x] Hardware") || !strings.Contains(view, "Module Type") {
+		t.Errorf("sidebar should show the checked value:\n%s", view)
+	}
+
+	// Checking Software too widens the list; the facets combine with the filter
+	newModel, _ = m.Update(keyMsg("j"))
+	newModel, _ = newModel.(Model).Update(keyMsg(" "))
+	m = newModel.(Model)
+	if got := len(m.list.Items()); got != 3 {
+		t.Errorf("got %d items, want hardware and software modules", got)
+	}
+	m.list.SetFilterText("vendor:acme")
+	if got := len(m.list.VisibleItems()); got != 2 {
+		t.Errorf("got %d visible items, want facets and filter combined", got)
+	}
+	m.list.ResetFilter()
+
+	// Esc hands j/k back to the list with the sidebar still open
+	newModel, _ = m.Update(keyMsg("esc"))
+	newModel, _ = newModel.(Model).Update(keyMsg("j"))
+	m = newModel.(Model)
+	if !m.showFacets || m.sidebarFocused || m.facetCursor != 1 || m.list.Index() != 1 {
+		t.Fatalf("expected j to move the list (index %d), not the sidebar (row %d)", m.list.Index(), m.facetCursor)
+	}
+
+	newModel, _ = m.Update(keyMsg("F"))
+	m = newModel.(Model)
+	if !m.showFacets || !m.sidebarFocused {
+		t.Fatal("expected F to focus the open sidebar")
+	}
+	newModel, _ = m.Update(keyMsg("F"))
+	m = newModel.(Model)
+	if m.showFacets || m.list.Width() != m.width-4 {
+		t.Error("expected F to close the sidebar")
+	}
+}
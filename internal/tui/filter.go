@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/query"
+)
+
+// queryFilter returns a list filter that evaluates the filter text as a
+// query. The list only passes each item's FilterValue, so the filter looks
+// the modules of items up by it. Modules sharing a FilterValue (the same
+// certificate, name and vendor) are taken in the order items lists them.
+func queryFilter(items []list.Item) list.FilterFunc {
+	byTarget := make(map[string][]*model.Module)
+	for _, item := range items {
+		if mi, ok := item.(model.ModuleItem); ok {
+			target := mi.FilterValue()
+			byTarget[target] = append(byTarget[target], &mi.Module)
+		}
+	}
+
+	return func(term string, targets []string) []list.Rank {
+		q, err := query.Parse(term)
+		if err != nil {
+			// renderStatusLine reports the error
+			return nil
+		}
+
+		var ranks []list.Rank
+		seen := make(map[string]int)
+		for i, target := range targets {
+			mods, n := byTarget[target], seen[target]
+			seen[target]++
+			if n < len(mods) && q.Match(mods[n]) {
+				ranks = append(ranks, list.Rank{Index: i})
+			}
+		}
+		return ranks
+	}
+}
+
+// setItems replaces the listed items with those on the current tab that match
+// the checked facets, in the chosen sort order, along with the filter's
+// snapshot
+func (m *Model) setItems(items []list.Item) tea.Cmd {
+	items = sortItems(m.narrow(items), m.sort, m.sortReverse)
+	m.itemsGen++
+	// Filter with the query language instead of fuzzy matching
+	m.list.Filter = queryFilter(items)
+	return m.list.SetItems(items)
+}
+
+// queryError returns the error in the current filter query, if any
+func (m Model) queryError() error {
+	if m.list.FilterState() == list.Unfiltered {
+		return nil
+	}
+	_, err := query.Parse(m.list.FilterValue())
+	return err
+}
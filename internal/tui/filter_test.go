@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestQueryFilter(t *testing.T) {
+	items := []list.Item{
+		model.ModuleItem{Module: model.Module{ModuleName: "A", VendorName: "OpenSSL", OverallLevel: model.NewSecurityLevel(1)}},
+		model.ModuleItem{Module: model.Module{ModuleName: "B", VendorName: "Acme", OverallLevel: model.NewSecurityLevel(3)}},
+		model.ModuleItem{Module: model.Module{ModuleName: "C", VendorName: "OpenSSL", OverallLevel: model.NewSecurityLevel(2)}},
+	}
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = item.FilterValue()
+	}
+	filter := queryFilter(items)
+
+	tests := []struct {
+		term string
+		want []int
+	}{
+		{"openssl", []int{0, 2}},
+		{"vendor:openssl level>=2", []int{2}},
+		{"level>=2 OR vendor:openssl", []int{0, 1, 2}},
+		{"vendor:", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			ranks := filter(tt.term, targets)
+			var got []int
+			for _, r := range ranks {
+				got = append(got, r.Index)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("matched %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestModel_QueryFilter(t *testing.T) {
+	m := newTestModel()
+	m.width = 120
+	m.height = 40
+
+	items := []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "Active Module", Status: model.StatusActive}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "2", ModuleName: "Old Module", Status: model.StatusHistorical}},
+	}
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: items})
+	m = newModel.(Model)
+
+	m.list.SetFilterText("status:historical")
+	if visible := m.list.VisibleItems(); len(visible) != 1 || visible[0].(model.ModuleItem).ModuleName != "Old Module" {
+		t.Errorf("expected only the historical module, got %v", visible)
+	}
+
+	m.list.SetFilterText(`name:"unterminated`)
+	if len(m.list.VisibleItems()) != 0 {
+		t.Error("expected an invalid query to match nothing")
+	}
+	if view := m.View(); !strings.Contains(view, "Invalid query: unterminated quote") {
+		t.Error("expected the status line to explain the invalid query")
+	}
+}
+
+func TestQueryFilter_TargetOrder(t *testing.T) {
+	items := testItems(
+		model.Module{CertificateNumber: "1", ModuleName: "A", VendorName: "OpenSSL"},
+		model.Module{CertificateNumber: "2", ModuleName: "B", VendorName: "Acme"},
+		model.Module{CertificateNumber: "3", ModuleName: "C", VendorName: "OpenSSL"},
+	)
+	filter := queryFilter(items)
+
+	// Targets are matched by value, not by their position in items
+	targets := []string{items[1].FilterValue(), items[0].FilterValue()}
+	var got []int
+	for _, r := range filter("name=a", targets) {
+		got = append(got, r.Index)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("matched %v, want [1]", got)
+	}
+}
+
+func TestModel_QueryFilter_AfterReorder(t *testing.T) {
+	m := loadedModel(t, testItems(
+		model.Module{CertificateNumber: "1", ModuleName: "Alpha", VendorName: "Acme", Status: model.StatusActive},
+		model.Module{CertificateNumber: "2", ModuleName: "Beta", VendorName: "Other", Status: model.StatusHistorical},
+		model.Module{CertificateNumber: "3", ModuleName: "Gamma", VendorName: "Acme", Status: model.StatusActive},
+	))
+
+	// Reverse the order and switch to the Active tab before filtering
+	newModel, _ := m.Update(keyMsg("S"))
+	newModel, _ = newModel.(Model).Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = newModel.(Model)
+
+	m.list.SetFilterText("name:gamma")
+	if visible := m.list.VisibleItems(); len(visible) != 1 || visible[0].(model.ModuleItem).ModuleName != "Gamma" {
+		t.Errorf("visible = %v, want only Gamma", visible)
+	}
+	m.list.SetFilterText("vendor:acme")
+	if got := len(m.list.VisibleItems()); got != 2 {
+		t.Errorf("got %d visible items, want both Acme modules", got)
+	}
+}
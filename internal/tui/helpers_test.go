@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// testItems wraps mods as list items
+func testItems(mods ...model.Module) []list.Item {
+	return toItems(mods)
+}
+
+// loadedModel returns a 120x40 model loaded with items, after applying
+// each option to the model before it loads
+func loadedModel(t *testing.T, items []list.Item, opts ...func(Model) Model) Model {
+	t.Helper()
+	m := newTestModel()
+	for _, opt := range opts {
+		m = opt(m)
+	}
+	m.width = 120
+	m.height = 40
+	newModel, _ := m.Update(ModulesLoadedMsg{Modules: items})
+	return newModel.(Model)
+}
+
+// keyMsg returns the key press named s
+func keyMsg(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
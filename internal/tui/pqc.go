@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/index"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// openPQC groups the loaded modules by post-quantum algorithm and shows
+// them in a scrollable report
+func (m Model) openPQC() Model {
+	modules := make([]model.Module, 0, len(m.allModules))
+	for _, item := range m.allModules {
+		if mi, ok := item.(model.ModuleItem); ok && mi.Status != model.StatusHistorical {
+			modules = append(modules, mi.Module)
+		}
+	}
+
+	var content string
+	if s, ok := m.source.(api.SummarySource); ok && s.SummaryOnly() {
+		content = HelpStyle.UnsetMarginTop().Render("This data source does not list module algorithms.")
+	} else {
+		content = buildPQCContent(index.NewAlgorithmIndex(modules).PQC())
+	}
+
+	m.pqcViewport = viewport.New(m.width-4, m.height-6)
+	m.pqcViewport.SetContent(content)
+	m.view = ViewPQC
+	return m
+}
+
+// pqcInProcessNote explains why no in-process module is listed: the
+// in-process dataset carries no algorithm data
+const pqcInProcessNote = "Algorithm data is not published for in-process modules."
+
+// buildPQCContent renders the standard coverage summary and the modules
+// implementing each post-quantum algorithm, most recently validated first
+func buildPQCContent(report index.PQCReport) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%d active module(s) from %d vendor(s) implement a post-quantum algorithm\n",
+		len(report.Modules), report.Vendors()))
+	b.WriteString(HelpStyle.UnsetMarginTop().Render(pqcInProcessNote))
+	b.WriteString("\n\n")
+
+	b.WriteString(DetailLabelStyle.Render("Coverage:"))
+	b.WriteString("\n")
+	for _, g := range report.Groups {
+		b.WriteString(fmt.Sprintf("  %-18s %-8s %3d active  %3d vendor(s)\n",
+			g.Standard, g.Name, g.Count(model.StatusActive), g.Vendors()))
+	}
+	b.WriteString(fmt.Sprintf("  %-18s %-8s %3d module(s)\n", "FIPS 203+204+205", "all", len(report.FullFIPS)))
+
+	for _, g := range report.Groups {
+		if len(g.Modules) == 0 {
+			continue
+		}
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.Render(fmt.Sprintf("%s (%s)", g.Name, g.Standard)))
+		b.WriteString("\n")
+		for _, mod := range g.Modules {
+			validated := "in process"
+			if !mod.ValidationDate.IsZero() {
+				validated = mod.ValidationDate.Format("2006-01-02")
+			}
+			cert := mod.CertificateNumber
+			if cert == "" {
+				cert = "-"
+			}
+			b.WriteString(fmt.Sprintf("  %-10s %-6s %s — %s\n",
+				validated, cert, truncate(mod.ModuleName, 50), truncate(mod.VendorName, 40)))
+		}
+	}
+	return b.String()
+}
+
+func (m Model) renderPQCView() string {
+	title := TitleStyle.Render("Post-Quantum Readiness")
+	help := HelpStyle.Render("j/k: scroll • esc: back")
+	return AppStyle.Render(title + "\n\n" + m.pqcViewport.View() + "\n" + help)
+}
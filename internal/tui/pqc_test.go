@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestModel_PQCView(t *testing.T) {
+	m := loadedModel(t, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "Older KEM", VendorName: "A",
+			Algorithms: []string{"ML-KEM"}, ValidationDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "2", ModuleName: "Newer KEM", VendorName: "B",
+			Algorithms: []string{"CRYSTALS-Kyber", "ML-DSA"}, ValidationDate: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "3", ModuleName: "Retired KEM", VendorName: "C",
+			Algorithms: []string{"ML-KEM"}, Status: model.StatusHistorical}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "4", ModuleName: "Classic", Algorithms: []string{"RSA"}}},
+	})
+
+	newModel, _ := m.Update(keyMsg("p"))
+	m = newModel.(Model)
+	if m.view != ViewPQC {
+		t.Fatalf("view = %v, want ViewPQC", m.view)
+	}
+
+	view := m.View()
+	for _, want := range []string{"Post-Quantum Readiness", "FIPS 203", "ML-KEM (FIPS 203)", "ML-DSA (FIPS 204)", "2025-03-04"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("PQC view missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"Retired KEM", "Classic"} {
+		if strings.Contains(view, unwanted) {
+			t.Errorf("PQC view should not list %q", unwanted)
+		}
+	}
+	if strings.Index(view, "Newer KEM") > strings.Index(view, "Older KEM") {
+		t.Error("expected the most recently validated module first")
+	}
+
+	newModel, _ = m.Update(keyMsg("esc"))
+	if newModel.(Model).view != ViewList {
+		t.Error("expected esc to return to the list")
+	}
+}
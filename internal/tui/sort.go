@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// sortMode is the order the module list is shown in
+type sortMode int
+
+const (
+	sortDefault   sortMode = iota // Dataset order: active, historical, in process
+	sortCert                      // Highest certificate number first
+	sortValidated                 // Newest validation first
+	sortSunset                    // Soonest sunset first
+	sortVendor                    // Vendor A-Z
+	sortName                      // Module name A-Z
+	sortLevel                     // Highest security level first
+	sortModes                     // Number of sort modes
+)
+
+func (s sortMode) String() string {
+	switch s {
+	case sortCert:
+		return "certificate #"
+	case sortValidated:
+		return "validation date"
+	case sortSunset:
+		return "sunset date"
+	case sortVendor:
+		return "vendor"
+	case sortName:
+		return "module name"
+	case sortLevel:
+		return "security level"
+	default:
+		return "dataset order"
+	}
+}
+
+// order describes the direction the mode sorts in
+func (s sortMode) order(reverse bool) string {
+	var forward, backward string
+	switch s {
+	case sortCert, sortLevel:
+		forward, backward = "highest first", "lowest first"
+	case sortValidated:
+		forward, backward = "newest first", "oldest first"
+	case sortSunset:
+		forward, backward = "soonest first", "latest first"
+	case sortVendor, sortName:
+		forward, backward = "A-Z", "Z-A"
+	default:
+		forward, backward = "", "reversed"
+	}
+	if reverse {
+		return backward
+	}
+	return forward
+}
+
+// sortItems returns the module items ordered by mode. Modules missing the
+// sort field always come last, and ties keep their dataset order.
+func sortItems(items []list.Item, mode sortMode, reverse bool) []list.Item {
+	sorted := append([]list.Item(nil), items...)
+	if mode == sortDefault {
+		if reverse {
+			for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+				sorted[i], sorted[j] = sorted[j], sorted[i]
+			}
+		}
+		return sorted
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, aok := sorted[i].(model.ModuleItem)
+		b, bok := sorted[j].(model.ModuleItem)
+		if !aok || !bok {
+			return aok
+		}
+		c, missing := compareModules(a.Module, b.Module, mode)
+		if missing != 0 {
+			return missing < 0
+		}
+		if reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+	return sorted
+}
+
+// compareModules orders a and b by mode, returning a negative c if a comes
+// first. missing is non-zero when only one of them has the field, and is
+// negative if a has it.
+func compareModules(a, b model.Module, mode sortMode) (c, missing int) {
+	switch mode {
+	case sortCert:
+		an, aerr := strconv.Atoi(a.CertificateNumber)
+		bn, berr := strconv.Atoi(b.CertificateNumber)
+		if m := missingLast(aerr == nil, berr == nil); m != 0 || aerr != nil {
+			return 0, m
+		}
+		return bn - an, 0
+	case sortValidated:
+		if m := missingLast(!a.ValidationDate.IsZero(), !b.ValidationDate.IsZero()); m != 0 || a.ValidationDate.IsZero() {
+			return 0, m
+		}
+		return b.ValidationDate.Compare(a.ValidationDate), 0
+	case sortSunset:
+		if m := missingLast(!a.SunsetDate.IsZero(), !b.SunsetDate.IsZero()); m != 0 || a.SunsetDate.IsZero() {
+			return 0, m
+		}
+		return a.SunsetDate.Compare(b.SunsetDate), 0
+	case sortVendor:
+		if m := missingLast(a.VendorName != "", b.VendorName != ""); m != 0 {
+			return 0, m
+		}
+		return strings.Compare(strings.ToLower(a.VendorName), strings.ToLower(b.VendorName)), 0
+	case sortName:
+		if m := missingLast(a.ModuleName != "", b.ModuleName != ""); m != 0 {
+			return 0, m
+		}
+		return strings.Compare(strings.ToLower(a.ModuleName), strings.ToLower(b.ModuleName)), 0
+	case sortLevel:
+		if m := missingLast(a.OverallLevel.Level > 0, b.OverallLevel.Level > 0); m != 0 {
+			return 0, m
+		}
+		return b.OverallLevel.Level - a.OverallLevel.Level, 0
+	}
+	return 0, 0
+}
+
+func missingLast(aok, bok bool) int {
+	switch {
+	case aok && !bok:
+		return -1
+	case bok && !aok:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func sortTestItems() []list.Item {
+	date := func(y int) time.Time { return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC) }
+	return testItems(
+		model.Module{CertificateNumber: "900", ModuleName: "beta", VendorName: "Zed",
+			ValidationDate: date(2020), SunsetDate: date(2025), OverallLevel: model.NewSecurityLevel(1)},
+		model.Module{CertificateNumber: "4500", ModuleName: "Alpha", VendorName: "acme",
+			ValidationDate: date(2024), SunsetDate: date(2029), OverallLevel: model.NewSecurityLevel(3)},
+		model.Module{CertificateNumber: "10", ModuleName: "Gamma", VendorName: "Mid",
+			ValidationDate: date(2016), OverallLevel: model.NewSecurityLevel(2)},
+		model.Module{ModuleName: "Pending", VendorName: "Queue", Status: model.StatusInProcess},
+	)
+}
+
+func names(items []list.Item) []string {
+	var out []string
+	for _, item := range items {
+		out = append(out, item.(model.ModuleItem).ModuleName)
+	}
+	return out
+}
+
+func TestSortItems(t *testing.T) {
+	tests := []struct {
+		mode    sortMode
+		reverse bool
+		want    []string
+	}{
+		{sortDefault, false, []string{"beta", "Alpha", "Gamma", "Pending"}},
+		{sortDefault, true, []string{"Pending", "Gamma", "Alpha", "beta"}},
+		{sortCert, false, []string{"Alpha", "beta", "Gamma", "Pending"}},
+		{sortCert, true, []string{"Gamma", "beta", "Alpha", "Pending"}},
+		{sortValidated, false, []string{"Alpha", "beta", "Gamma", "Pending"}},
+		{sortSunset, false, []string{"beta", "Alpha", "Gamma", "Pending"}},
+		{sortSunset, true, []string{"Alpha", "beta", "Gamma", "Pending"}},
+		{sortVendor, false, []string{"Alpha", "Gamma", "Pending", "beta"}},
+		{sortName, false, []string{"Alpha", "beta", "Gamma", "Pending"}},
+		{sortName, true, []string{"Pending", "Gamma", "beta", "Alpha"}},
+		{sortLevel, false, []string{"Alpha", "Gamma", "beta", "Pending"}},
+	}
+
+	items := sortTestItems()
+	for _, tt := range tests {
+		got := names(sortItems(items, tt.mode, tt.reverse))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("sort by %s (reverse=%v) = %v, want %v", tt.mode, tt.reverse, got, tt.want)
+		}
+	}
+	if got := names(items); !reflect.DeepEqual(got, []string{"beta", "Alpha", "Gamma", "Pending"}) {
+		t.Errorf("sorting should not modify the input, got %v", got)
+	}
+}
+
+func TestModel_SortKeys(t *testing.T) {
+	m := loadedModel(t, sortTestItems())
+
+	// s cycles from dataset order to certificate number to validation date
+	for range 2 {
+		newModel, _ := m.Update(keyMsg("s"))
+		m = newModel.(Model)
+	}
+	if m.sort != sortValidated {
+		t.Fatalf("sort = %s, want validation date", m.sort)
+	}
+	if got := names(m.list.Items()); got[0] != "Alpha" {
+		t.Errorf("items = %v, want the newest validation first", got)
+	}
+	if status := m.renderStatusLine(); !strings.Contains(status, "Sorted by validation date, newest first") {
+		t.Errorf("status line = %q, want the sort indicator", status)
+	}
+
+	newModel, _ := m.Update(keyMsg("S"))
+	m = newModel.(Model)
+	if got := names(m.list.Items()); got[0] != "Gamma" {
+		t.Errorf("items = %v, want the oldest validation first after reversing", got)
+	}
+
+	// The sort survives filtering and a data refresh
+	m.list.SetFilterText("vendor:e")
+	if got := names(m.list.VisibleItems()); !reflect.DeepEqual(got, []string{"beta", "Alpha", "Pending"}) {
+		t.Errorf("filtered items = %v, want the sort kept", got)
+	}
+	newModel, _ = m.Update(ModulesLoadedMsg{Modules: sortTestItems()})
+	m = newModel.(Model)
+	if got := names(m.list.Items()); got[0] != "Gamma" {
+		t.Errorf("items = %v, want the sort kept after a refresh", got)
+	}
+}
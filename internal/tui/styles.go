@@ -1,22 +1,27 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/ui"
 )
 
 var (
-	// Color palette
-	PrimaryColor   = lipgloss.Color("#7D56F4") // Purple
-	SecondaryColor = lipgloss.Color("#04B575") // Green
-	WarningColor   = lipgloss.Color("#FFCC00") // Yellow
-	ErrorColor     = lipgloss.Color("#FF5F56") // Red
-	SubtleColor    = lipgloss.Color("#626262") // Gray
+	// Color palette, shared with the CLI
+	PrimaryColor   = ui.PrimaryColor
+	SecondaryColor = ui.SecondaryColor
+	WarningColor   = ui.WarningColor
+	ErrorColor     = ui.ErrorColor
+	SubtleColor    = ui.SubtleColor
 
 	// Status colors
-	ActiveColor     = lipgloss.Color("#04B575") // Green
-	HistoricalColor = lipgloss.Color("#626262") // Gray
-	InProcessColor  = lipgloss.Color("#FFCC00") // Yellow
+	ActiveColor     = ui.ActiveColor
+	HistoricalColor = ui.HistoricalColor
+	InProcessColor  = ui.InProcessColor
 
 	// App styles
 	AppStyle = lipgloss.NewStyle().
@@ -32,22 +37,11 @@ var (
 			Foreground(SubtleColor).
 			Padding(0, 1)
 
-	// Detail view styles
-	DetailTitleStyle = lipgloss.NewStyle().
-				Foreground(PrimaryColor).
-				Bold(true).
-				MarginBottom(1)
-
-	DetailLabelStyle = lipgloss.NewStyle().
-				Foreground(SubtleColor).
-				Width(18)
-
-	DetailValueStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FAFAFA"))
-
-	DetailURLStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#00AAFF")).
-			Underline(true)
+	// Detail view styles, shared with cmvp show
+	DetailTitleStyle = ui.DetailTitleStyle
+	DetailLabelStyle = ui.DetailLabelStyle
+	DetailValueStyle = ui.DetailValueStyle
+	DetailURLStyle   = ui.DetailURLStyle
 
 	// Status badge styles
 	ActiveBadge = lipgloss.NewStyle().
@@ -65,17 +59,18 @@ var (
 			Background(InProcessColor).
 			Padding(0, 1)
 
+	// Non-fatal warning banner (e.g. a dataset failed to load)
+	WarningBannerStyle = lipgloss.NewStyle().
+				Foreground(WarningColor).
+				Bold(true)
+
 	// Help style
 	HelpStyle = lipgloss.NewStyle().
 			Foreground(SubtleColor).
 			MarginTop(1)
 
 	// Caveat warning style (important security warnings)
-	CaveatStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFFFF")).
-			Background(lipgloss.Color("#FF6B6B")).
-			Padding(0, 1).
-			Bold(true)
+	CaveatStyle = ui.CaveatStyle
 
 	// Level badge styles (color coded by security level)
 	Level1Badge = lipgloss.NewStyle().
@@ -98,6 +93,89 @@ var (
 			Background(lipgloss.Color("#FF5F56")).
 			Padding(0, 1)
 
+	// Detailed algorithm table styles
+	AlgorithmTableHeaderStyle = ui.HeaderStyle
+
+	NonApprovedStyle = lipgloss.NewStyle().
+				Foreground(ErrorColor)
+
+	// Status tabs above the module list
+	ActiveTabStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFDF5")).
+			Background(PrimaryColor).
+			Padding(0, 1).
+			Bold(true)
+
+	InactiveTabStyle = lipgloss.NewStyle().
+				Foreground(SubtleColor).
+				Padding(0, 1)
+
+	// Facet sidebar styles
+	FacetSidebarStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(SubtleColor).
+				Padding(0, 1)
+
+	FacetHeaderStyle = lipgloss.NewStyle().
+				Foreground(PrimaryColor).
+				Bold(true)
+
+	FacetCursorStyle = lipgloss.NewStyle().
+				Foreground(PrimaryColor)
+
+	// Compare view styles
+	MarkedBadge = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFDF5")).
+			Background(PrimaryColor).
+			Padding(0, 1)
+
+	CompareDiffStyle = lipgloss.NewStyle().
+				Foreground(WarningColor).
+				Bold(true)
+
+	// WatchedBadge marks modules on the watchlist
+	WatchedBadge = lipgloss.NewStyle().
+			Foreground(WarningColor).
+			Bold(true)
+
+	// Export confirmation toasts in the status line
+	ToastStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(SecondaryColor).
+			Padding(0, 1)
+
+	ToastErrorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(ErrorColor).
+			Padding(0, 1)
+
+	// QueryErrorStyle reports an invalid filter query in the status line
+	QueryErrorStyle = lipgloss.NewStyle().
+			Foreground(ErrorColor).
+			Padding(0, 1)
+
+	// Sunset countdown badges, by urgency
+	SunsetUrgentBadge = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(ErrorColor).
+				Padding(0, 1)
+
+	SunsetSoonBadge = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#000000")).
+			Background(WarningColor).
+			Padding(0, 1)
+
+	SunsetLaterBadge = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFFFF")).
+				Background(SecondaryColor).
+				Padding(0, 1)
+
+	// LevelTextBadge is used for levels published as text or per area
+	LevelTextBadge = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(SubtleColor).
+			Padding(0, 1)
+
 	// Algorithm tag style
 	AlgorithmStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FFFFFF")).
@@ -125,12 +203,15 @@ func StatusBadge(status model.ModuleStatus) string {
 	}
 }
 
-// LevelBadge returns a color-coded security level badge
-func LevelBadge(level int) string {
-	if level == 0 {
+// LevelBadge returns a color-coded security level badge. Levels published
+// as text or per area get a neutral badge.
+func LevelBadge(level model.SecurityLevel) string {
+	switch level.Level {
+	case 0:
+		if text := strings.SplitN(level.String(), "\n", 2)[0]; text != "" {
+			return LevelTextBadge.Render(truncate(text, 32))
+		}
 		return ""
-	}
-	switch level {
 	case 1:
 		return Level1Badge.Render("Level 1")
 	case 2:
@@ -143,3 +224,41 @@ func LevelBadge(level int) string {
 		return ""
 	}
 }
+
+// Sunset urgency thresholds in days
+const (
+	sunsetUrgentDays = 90
+	sunsetSoonDays   = 365
+)
+
+// SunsetBadge returns a countdown to an active module's sunset date, colored
+// by how soon it moves to historical. Other modules get no badge.
+func SunsetBadge(mod model.Module, now time.Time) string {
+	days, ok := mod.DaysUntilSunset(now)
+	if !ok || mod.Status != model.StatusActive {
+		return ""
+	}
+	switch {
+	case days < 0:
+		return SunsetUrgentBadge.Render("Sunset passed")
+	case days == 0:
+		return SunsetUrgentBadge.Render("Sunsets today")
+	case days == 1:
+		return SunsetUrgentBadge.Render("Sunset in 1 day")
+	case days <= sunsetUrgentDays:
+		return SunsetUrgentBadge.Render(fmt.Sprintf("Sunset in %d days", days))
+	case days <= sunsetSoonDays:
+		return SunsetSoonBadge.Render(fmt.Sprintf("Sunset in %d days", days))
+	default:
+		return SunsetLaterBadge.Render(fmt.Sprintf("Sunset in %.1f years", float64(days)/365))
+	}
+}
+
+// truncate shortens s to at most n runes, ending with an ellipsis if cut
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
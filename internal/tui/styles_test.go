@@ -3,6 +3,7 @@ package tui
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethanolivertroy/cmvp-tui/internal/model"
 )
@@ -58,49 +59,61 @@ func TestStatusBadge(t *testing.T) {
 func TestLevelBadge(t *testing.T) {
 	tests := []struct {
 		name     string
-		level    int
+		level    model.SecurityLevel
 		contains string
 		empty    bool
 	}{
 		{
 			name:  "level 0",
-			level: 0,
+			level: model.NewSecurityLevel(0),
 			empty: true,
 		},
 		{
 			name:     "level 1",
-			level:    1,
+			level:    model.NewSecurityLevel(1),
 			contains: "Level 1",
 			empty:    false,
 		},
 		{
 			name:     "level 2",
-			level:    2,
+			level:    model.NewSecurityLevel(2),
 			contains: "Level 2",
 			empty:    false,
 		},
 		{
 			name:     "level 3",
-			level:    3,
+			level:    model.NewSecurityLevel(3),
 			contains: "Level 3",
 			empty:    false,
 		},
 		{
 			name:     "level 4",
-			level:    4,
+			level:    model.NewSecurityLevel(4),
 			contains: "Level 4",
 			empty:    false,
 		},
 		{
 			name:  "level 5 (invalid)",
-			level: 5,
+			level: model.NewSecurityLevel(5),
 			empty: true,
 		},
 		{
 			name:  "negative level",
-			level: -1,
+			level: model.NewSecurityLevel(-1),
 			empty: true,
 		},
+		{
+			name:     "text level",
+			level:    model.ParseSecurityLevel("Tested Configuration(s)"),
+			contains: "Tested Configuration(s)",
+			empty:    false,
+		},
+		{
+			name:     "per-area levels",
+			level:    model.ParseSecurityLevel("Physical Security: 3\nRoles, Services, and Authentication: 2"),
+			contains: "Levels 2-3",
+			empty:    false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -108,7 +121,7 @@ func TestLevelBadge(t *testing.T) {
 			result := LevelBadge(tt.level)
 			if tt.empty {
 				if result != "" {
-					t.Errorf("expected empty string for level %d, got %q", tt.level, result)
+					t.Errorf("expected empty string for level %+v, got %q", tt.level, result)
 				}
 			} else {
 				if !strings.Contains(result, tt.contains) {
@@ -143,3 +156,66 @@ func TestColorConstants(t *testing.T) {
 		})
 	}
 }
+
+func TestSunsetBadge(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	day := func(n int) time.Time {
+		return time.Date(2026, 10, 17+n, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		mod      model.Module
+		contains string
+		empty    bool
+	}{
+		{
+			name:  "no sunset date",
+			mod:   model.Module{Status: model.StatusActive},
+			empty: true,
+		},
+		{
+			name:  "historical module",
+			mod:   model.Module{Status: model.StatusHistorical, SunsetDate: day(-30)},
+			empty: true,
+		},
+		{
+			name:     "passed",
+			mod:      model.Module{Status: model.StatusActive, SunsetDate: day(-1)},
+			contains: "Sunset passed",
+		},
+		{
+			name:     "today",
+			mod:      model.Module{Status: model.StatusActive, SunsetDate: day(0)},
+			contains: "Sunsets today",
+		},
+		{
+			name:     "urgent",
+			mod:      model.Module{Status: model.StatusActive, SunsetDate: day(45)},
+			contains: "Sunset in 45 days",
+		},
+		{
+			name:     "soon",
+			mod:      model.Module{Status: model.StatusActive, SunsetDate: day(200)},
+			contains: "Sunset in 200 days",
+		},
+		{
+			name:     "later",
+			mod:      model.Module{Status: model.StatusActive, SunsetDate: day(730)},
+			contains: "Sunset in 2.0 years",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SunsetBadge(tt.mod, now)
+			if tt.empty {
+				if result != "" {
+					t.Errorf("expected no badge, got %q", result)
+				}
+			} else if !strings.Contains(result, tt.contains) {
+				t.Errorf("expected badge to contain %q, got %q", tt.contains, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/watchlist"
+)
+
+// errNoWatchlist is reported when w is pressed without a watchlist file
+var errNoWatchlist = errors.New("no watchlist file is available")
+
+// WithWatchlist returns the model with w as its watchlist. Without one the
+// watchlist keys report that it is unavailable.
+func (m Model) WithWatchlist(w *watchlist.Watchlist) Model {
+	m.watchlist = w
+	return m
+}
+
+// watchItem is an entry in the watchlist view
+type watchItem struct {
+	entry   watchlist.Entry
+	module  *model.Module // nil if the module is not in the loaded data
+	changes []string      // What changed since the last launch
+}
+
+// Title returns the certificate number and module name
+func (w watchItem) Title() string {
+	title := w.entry.ModuleName
+	if w.entry.CertificateNumber != "" {
+		title = fmt.Sprintf("[%s] %s", w.entry.CertificateNumber, title)
+	}
+	if len(w.changes) > 0 {
+		title = "● " + title
+	}
+	return title
+}
+
+// Description returns the status, days to sunset and any changes
+func (w watchItem) Description() string {
+	if w.module == nil {
+		return w.entry.VendorName + " | not in the loaded data"
+	}
+	parts := []string{w.entry.VendorName, w.module.Status.String()}
+	if w.module.Stage != "" {
+		parts[1] += ": " + string(w.module.Stage)
+	}
+	if days, ok := w.module.DaysUntilSunset(now()); ok {
+		switch {
+		case days < 0:
+			parts = append(parts, fmt.Sprintf("sunset %d days ago", -days))
+		case days == 1:
+			parts = append(parts, "1 day to sunset")
+		default:
+			parts = append(parts, fmt.Sprintf("%d days to sunset", days))
+		}
+	}
+	if len(w.changes) > 0 {
+		parts = append(parts, "Changed: "+strings.Join(w.changes, ", "))
+	}
+	return strings.Join(parts, " | ")
+}
+
+// FilterValue returns the string used for filtering
+func (w watchItem) FilterValue() string {
+	return w.entry.CertificateNumber + " " + w.entry.ModuleName + " " + w.entry.VendorName
+}
+
+// loadedModules returns the modules of every loaded list item
+func (m Model) loadedModules() []model.Module {
+	modules := make([]model.Module, 0, len(m.allModules))
+	for _, item := range m.allModules {
+		if mi, ok := item.(model.ModuleItem); ok {
+			modules = append(modules, mi.Module)
+		}
+	}
+	return modules
+}
+
+// refreshWatchlist records the loaded state of the watched modules so the
+// next launch can report what changed, and counts what changed since the last
+func (m *Model) refreshWatchlist() {
+	if m.watchlist.Len() == 0 {
+		return
+	}
+	modules := m.loadedModules()
+	m.watchlist.Refresh(modules)
+	m.watchErr = m.watchlist.Save()
+
+	m.watchChanged = 0
+	for _, mod := range modules {
+		if m.watchlist.Contains(mod.Key()) && len(m.watchlist.Changes(mod)) > 0 {
+			m.watchChanged++
+		}
+	}
+}
+
+// toggleWatch starts or stops watching mod and saves the watchlist
+func (m *Model) toggleWatch(mod model.Module) {
+	if m.watchlist == nil {
+		m.watchErr = errNoWatchlist
+		return
+	}
+	m.watchlist.Toggle(mod, now())
+	m.watchErr = m.watchlist.Save()
+}
+
+// watchItems lists the watched modules, those that changed first
+func (m Model) watchItems() []list.Item {
+	byKey := make(map[string]model.Module, len(m.allModules))
+	for _, mod := range m.loadedModules() {
+		byKey[mod.Key()] = mod
+	}
+
+	var items []watchItem
+	for _, e := range m.watchlist.Entries() {
+		item := watchItem{entry: e}
+		if mod, ok := byKey[e.Key]; ok {
+			item.module = &mod
+			item.changes = m.watchlist.Changes(mod)
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return len(items[i].changes) > 0 && len(items[j].changes) == 0
+	})
+
+	listItems := make([]list.Item, len(items))
+	for i, item := range items {
+		listItems[i] = item
+	}
+	return listItems
+}
+
+// openWatchlist shows the watched modules
+func (m Model) openWatchlist() Model {
+	if m.watchlist == nil {
+		m.watchErr = errNoWatchlist
+		return m
+	}
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.Foreground(PrimaryColor).BorderForeground(PrimaryColor)
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedDesc.BorderForeground(PrimaryColor)
+
+	m.watchList = list.New(m.watchItems(), delegate, m.width-4, m.height-6)
+	m.watchList.Title = "Watchlist"
+	m.watchList.Styles.Title = TitleStyle
+	m.watchList.SetShowStatusBar(true)
+	m.watchList.SetStatusBarItemName("module", "modules")
+	m.watchList.FilterInput.Prompt = "Filter: "
+	m.watchList.FilterInput.PromptStyle = lipgloss.NewStyle().Foreground(PrimaryColor)
+	m.view = ViewWatchlist
+	return m
+}
+
+// unwatchSelected stops watching the module selected in the watchlist view
+func (m Model) unwatchSelected() Model {
+	item, ok := m.watchList.SelectedItem().(watchItem)
+	if !ok {
+		return m
+	}
+	mod := model.Module{CertificateNumber: item.entry.CertificateNumber, VendorName: item.entry.VendorName, ModuleName: item.entry.ModuleName}
+	m.toggleWatch(mod)
+	m.watchList.SetItems(m.watchItems())
+	return m
+}
+
+// openWatchedModule shows the detail view of the module selected in the
+// watchlist view
+func (m Model) openWatchedModule() (Model, tea.Cmd) {
+	item, ok := m.watchList.SelectedItem().(watchItem)
+	if !ok || item.module == nil {
+		return m, nil
+	}
+	return m.openDetail(model.ModuleItem{Module: *item.module}, ViewWatchlist)
+}
+
+func (m Model) renderWatchlistView() string {
+	help := "enter: details • w: stop watching • /: filter • esc: back"
+	if m.watchErr != nil {
+		help = fmt.Sprintf("Watchlist not saved: %v • %s", m.watchErr, help)
+	}
+	return AppStyle.Render(m.watchList.View() + "\n" + HelpStyle.Render(help))
+}
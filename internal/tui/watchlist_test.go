@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/watchlist"
+)
+
+func watchTestItems() []list.Item {
+	return testItems(
+		model.Module{CertificateNumber: "4282", ModuleName: "OpenSSL FIPS Provider", VendorName: "OpenSSL",
+			Status: model.StatusActive, SunsetDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		model.Module{CertificateNumber: "100", ModuleName: "Other", VendorName: "Acme", Status: model.StatusActive},
+	)
+}
+
+// watchedModel returns a model with a watchlist in a temp dir, loaded with items
+func watchedModel(t *testing.T, path string, items []list.Item) Model {
+	t.Helper()
+	w, err := watchlist.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loadedModel(t, items, func(m Model) Model { return m.WithWatchlist(w) })
+}
+
+func TestModel_Watchlist(t *testing.T) {
+	pinNow(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+	m := watchedModel(t, path, watchTestItems())
+
+	newModel, _ := m.Update(keyMsg("w"))
+	m = newModel.(Model)
+	if m.watchErr != nil || !m.watchlist.Contains("4282") {
+		t.Fatalf("expected w to watch the selected module, err = %v", m.watchErr)
+	}
+
+	// The next launch reports what changed since
+	items := watchTestItems()
+	changed := items[0].(model.ModuleItem)
+	changed.Status = model.StatusHistorical
+	items[0] = changed
+	m = watchedModel(t, path, items)
+	if !strings.Contains(m.renderStatusLine(), "1 watched module(s) changed") {
+		t.Errorf("status line = %q, want the changed count", m.renderStatusLine())
+	}
+
+	newModel, _ = m.Update(keyMsg("W"))
+	m = newModel.(Model)
+	if m.view != ViewWatchlist {
+		t.Fatalf("view = %v, want ViewWatchlist", m.view)
+	}
+	view := m.View()
+	for _, want := range []string{"Watchlist", "4282", "Historical", "59 days to sunset", "Status Active → Historical"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("watchlist view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Details opened from the watchlist return to it
+	newModel, _ = m.Update(keyMsg("enter"))
+	m = newModel.(Model)
+	if m.view != ViewDetail || !strings.Contains(m.View(), "Watching") {
+		t.Fatalf("expected enter to open the watched module's details")
+	}
+	newModel, _ = m.Update(keyMsg("esc"))
+	m = newModel.(Model)
+	if m.view != ViewWatchlist {
+		t.Errorf("view = %v, want the watchlist after leaving details", m.view)
+	}
+
+	newModel, _ = m.Update(keyMsg("w"))
+	m = newModel.(Model)
+	if m.watchlist.Len() != 0 || len(m.watchList.Items()) != 0 {
+		t.Error("expected w in the watchlist view to stop watching the module")
+	}
+	if reloaded, _ := watchlist.Load(path); reloaded.Len() != 0 {
+		t.Error("expected the watchlist to be saved")
+	}
+}
+
+func TestModel_Watchlist_Unavailable(t *testing.T) {
+	m := loadedModel(t, watchTestItems())
+
+	newModel, _ := m.Update(keyMsg("w"))
+	m = newModel.(Model)
+	if m.watchErr != errNoWatchlist {
+		t.Errorf("watchErr = %v, want errNoWatchlist", m.watchErr)
+	}
+	newModel, _ = m.Update(keyMsg("W"))
+	if newModel.(Model).view != ViewList {
+		t.Error("expected the watchlist view not to open without a watchlist")
+	}
+}
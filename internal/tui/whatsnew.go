@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/snapshot"
+)
+
+// WhatsNewMsg is sent when the loaded data has been compared with the last
+// snapshot the user saw
+type WhatsNewMsg struct {
+	Diff    *snapshot.Diff     // nil if there was no earlier snapshot
+	Current *snapshot.Snapshot // Saved as last seen once the user views it
+	Err     error
+}
+
+// snapshotSavedMsg reports whether the last seen snapshot was saved
+type snapshotSavedMsg struct {
+	Err error
+}
+
+// WithSnapshotPath returns the model with path as the last seen snapshot
+// that the What's new view compares against. Without one the view is
+// unavailable.
+func (m Model) WithSnapshotPath(path string) Model {
+	m.snapshotPath = path
+	return m
+}
+
+// compareSnapshot compares the loaded data with the last seen snapshot.
+// On first use the loaded data is saved as the baseline.
+func (m Model) compareSnapshot() tea.Cmd {
+	if m.snapshotPath == "" {
+		return nil
+	}
+	path := m.snapshotPath
+	current := snapshot.New(m.loadedModules(), m.dataAsOf, now())
+	return func() tea.Msg {
+		last, err := snapshot.Load(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return WhatsNewMsg{Current: current, Err: current.Save(path)}
+		}
+		if err != nil {
+			return WhatsNewMsg{Current: current, Err: err}
+		}
+		d := snapshot.Compare(last, current)
+		return WhatsNewMsg{Diff: &d, Current: current}
+	}
+}
+
+// openWhatsNew shows what changed since the last seen snapshot and records
+// the loaded data as seen
+func (m Model) openWhatsNew() (Model, tea.Cmd) {
+	var content string
+	switch {
+	case m.snapshotPath == "":
+		content = HelpStyle.UnsetMarginTop().Render("No cache directory is available to keep snapshots in.")
+	case m.whatsNewErr != nil:
+		content = QueryErrorStyle.UnsetPadding().Render(fmt.Sprintf("Could not compare with the last snapshot: %v", m.whatsNewErr))
+	case m.whatsNew == nil:
+		content = HelpStyle.UnsetMarginTop().Render("Nothing to compare with yet. Changes will show here once NIST publishes new data.")
+	default:
+		content = buildWhatsNewContent(*m.whatsNew)
+	}
+
+	m.whatsNewViewport = viewport.New(m.width-4, m.height-6)
+	m.whatsNewViewport.SetContent(content)
+	m.view = ViewWhatsNew
+
+	if m.whatsNewCurrent == nil || m.whatsNewSeen {
+		return m, nil
+	}
+	m.whatsNewSeen = true
+	current, path := m.whatsNewCurrent, m.snapshotPath
+	return m, func() tea.Msg {
+		return snapshotSavedMsg{Err: current.Save(path)}
+	}
+}
+
+// buildWhatsNewContent renders the certificates added, removed and changed
+func buildWhatsNewContent(d snapshot.Diff) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Since %s (now %s)\n", formatDataAsOf(d.From), formatDataAsOf(d.To)))
+	if d.Empty() {
+		b.WriteString("\nNo certificates were added, removed or changed.\n")
+		return b.String()
+	}
+
+	writeRecords := func(title string, records []snapshot.Record) {
+		if len(records) == 0 {
+			return
+		}
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.UnsetWidth().Render(fmt.Sprintf("%s (%d)", title, len(records))))
+		b.WriteString("\n")
+		for _, r := range records {
+			b.WriteString(fmt.Sprintf("  #%-6s %s — %s (%s)\n",
+				r.CertificateNumber, truncate(r.ModuleName, 50), truncate(r.VendorName, 40), r.Status))
+		}
+	}
+	writeRecords("Added", d.Added)
+	writeRecords("Removed", d.Removed)
+
+	if len(d.Changed) > 0 {
+		b.WriteString("\n")
+		b.WriteString(DetailLabelStyle.UnsetWidth().Render(fmt.Sprintf("Changed (%d)", len(d.Changed))))
+		b.WriteString("\n")
+		for _, c := range d.Changed {
+			b.WriteString(fmt.Sprintf("  #%-6s %s — %s\n",
+				c.Module.CertificateNumber, truncate(c.Module.ModuleName, 50), truncate(c.Module.VendorName, 40)))
+			for _, line := range c.Descriptions() {
+				b.WriteString("          " + CompareDiffStyle.Render(line) + "\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+func (m Model) renderWhatsNewView() string {
+	title := TitleStyle.Render("What's New")
+	help := HelpStyle.Render("j/k: scroll • esc: back")
+	return AppStyle.Render(title + "\n\n" + m.whatsNewViewport.View() + "\n" + help)
+}
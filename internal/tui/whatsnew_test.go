@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+	"github.com/ethanolivertroy/cmvp-tui/internal/snapshot"
+)
+
+// loadWithSnapshot loads items into a model that keeps its last seen
+// snapshot at path, and delivers the resulting comparison
+func loadWithSnapshot(t *testing.T, path string, items []list.Item) Model {
+	t.Helper()
+	m := newTestModel().WithSnapshotPath(path)
+	m.width = 120
+	m.height = 40
+	newModel, cmd := m.Update(ModulesLoadedMsg{Modules: items, GeneratedAt: "2026-02-01T00:00:00Z"})
+	m = newModel.(Model)
+
+	if cmd == nil {
+		t.Fatal("expected commands after loading")
+	}
+	msg := cmd()
+	batch, ok := msg.(tea.BatchMsg)
+	if !ok {
+		batch = tea.BatchMsg{func() tea.Msg { return msg }}
+	}
+	for _, c := range batch {
+		if c == nil {
+			continue
+		}
+		if msg, ok := c().(WhatsNewMsg); ok {
+			newModel, _ = m.Update(msg)
+			return newModel.(Model)
+		}
+	}
+	t.Fatal("loading did not compare with the last seen snapshot")
+	return m
+}
+
+func TestModel_WhatsNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "last-seen.json")
+	older := snapshot.New([]model.Module{
+		{CertificateNumber: "1", ModuleName: "Provider", Status: model.StatusActive},
+		{CertificateNumber: "2", ModuleName: "Retired HSM", Status: model.StatusActive},
+	}, "2026-01-01T00:00:00Z", time.Now())
+	if err := older.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	m := loadWithSnapshot(t, path, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "Provider", Status: model.StatusHistorical}},
+		model.ModuleItem{Module: model.Module{CertificateNumber: "3", ModuleName: "New HSM", Status: model.StatusActive}},
+	})
+	if !strings.Contains(m.View(), "3 certificate(s) changed since you last looked") {
+		t.Errorf("status line should announce the changes:\n%s", m.View())
+	}
+
+	newModel, cmd := m.Update(keyMsg("n"))
+	m = newModel.(Model)
+	if m.view != ViewWhatsNew {
+		t.Fatalf("view = %v, want ViewWhatsNew", m.view)
+	}
+	view := m.View()
+	for _, want := range []string{"What's New", "Added (1)", "New HSM", "Removed (1)", "Retired HSM", "Changed (1)", "Status Active → Historical"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("what's new view missing %q:\n%s", want, view)
+		}
+	}
+
+	// Viewing the changes records the loaded data as seen
+	if cmd == nil {
+		t.Fatal("expected the snapshot to be saved once viewed")
+	}
+	newModel, _ = m.Update(cmd())
+	m = newModel.(Model)
+	if m.whatsNewErr != nil {
+		t.Fatal(m.whatsNewErr)
+	}
+	seen, err := snapshot.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seen.GeneratedAt != "2026-02-01T00:00:00Z" {
+		t.Errorf("last seen snapshot = %s, want the loaded data", seen.GeneratedAt)
+	}
+
+	newModel, _ = m.Update(keyMsg("esc"))
+	m = newModel.(Model)
+	if m.view != ViewList || strings.Contains(m.View(), "since you last looked") {
+		t.Error("esc should return to the list, which no longer announces seen changes")
+	}
+}
+
+func TestModel_WhatsNew_FirstRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "last-seen.json")
+	m := loadWithSnapshot(t, path, []list.Item{
+		model.ModuleItem{Module: model.Module{CertificateNumber: "1", ModuleName: "Provider"}},
+	})
+	if _, err := snapshot.Load(path); err != nil {
+		t.Fatalf("the first load should be saved as the baseline: %v", err)
+	}
+
+	newModel, cmd := m.Update(keyMsg("n"))
+	m = newModel.(Model)
+	if cmd != nil {
+		t.Error("the baseline is already saved")
+	}
+	if !strings.Contains(m.View(), "Nothing to compare with yet") {
+		t.Errorf("view:\n%s", m.View())
+	}
+}
+
+func TestModel_WhatsNew_Unavailable(t *testing.T) {
+	m := loadedModel(t, []list.Item{model.ModuleItem{Module: model.Module{CertificateNumber: "1"}}})
+	newModel, _ := m.Update(keyMsg("n"))
+	m = newModel.(Model)
+	if !strings.Contains(m.View(), "No cache directory") {
+		t.Errorf("view:\n%s", m.View())
+	}
+}
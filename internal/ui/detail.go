@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// DetailField is a labelled row of the module details
+type DetailField struct {
+	Label     string
+	Value     string
+	IsURL     bool
+	Badged    bool // Already shown in full by a level badge in the TUI's detail title
+	Highlight bool // Differences are highlighted in the compare view
+}
+
+// DetailFields returns the rows of the details grid for mod, with
+// sunsetNote, such as a countdown, after the sunset date. Empty values are
+// kept so the compare view can align the rows of several modules.
+func DetailFields(mod model.Module, sunsetNote string) []DetailField {
+	sunset := FormatLongDate(mod.SunsetDate)
+	if sunset != "" && sunsetNote != "" {
+		sunset += "  " + sunsetNote
+	}
+
+	// Levels published as text are shown in full; the badge is truncated
+	level := mod.OverallLevel
+	levelBadged := level.Level != 0 || len(level.Areas) > 0
+
+	return []DetailField{
+		{Label: "Certificate #:", Value: mod.CertificateNumber},
+		{Label: "Vendor:", Value: mod.VendorName},
+		{Label: "Module Type:", Value: mod.ModuleType},
+		{Label: "Review Stage:", Value: string(mod.Stage)},
+		{Label: "Standard:", Value: mod.Standard},
+		{Label: "Embodiment:", Value: mod.Embodiment},
+		{Label: "Lab:", Value: mod.Lab},
+		{Label: "Overall Level:", Value: level.String(), Badged: levelBadged, Highlight: true},
+		{Label: "Validation Date:", Value: FormatLongDate(mod.ValidationDate)},
+		{Label: "Sunset Date:", Value: sunset, Highlight: true},
+		{Label: "NIST URL:", Value: mod.CertificateURL, IsURL: true},
+		{Label: "Security Policy:", Value: mod.SecurityPolicyURL, IsURL: true},
+	}
+}
+
+// FormatLongDate formats t as e.g. "January 2, 2006", or "" if it is zero
+func FormatLongDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("January 2, 2006")
+}
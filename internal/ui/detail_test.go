@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+func TestDetailFields(t *testing.T) {
+	mod := model.Module{
+		CertificateNumber: "4282",
+		OverallLevel:      model.NewSecurityLevel(2),
+		SunsetDate:        time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+		CertificateURL:    "https://csrc.nist.gov/4282",
+	}
+
+	fields := make(map[string]DetailField)
+	for _, f := range DetailFields(mod, "(in 30 days)") {
+		fields[f.Label] = f
+	}
+	if got := fields["Sunset Date:"].Value; got != "January 1, 2029  (in 30 days)" {
+		t.Errorf("sunset = %q, want the date followed by the note", got)
+	}
+	if f := fields["Overall Level:"]; f.Value != "Level 2" || !f.Badged || !f.Highlight {
+		t.Errorf("overall level = %+v", f)
+	}
+	if !fields["NIST URL:"].IsURL {
+		t.Error("NIST URL should be marked as a URL")
+	}
+	if f, ok := fields["Lab:"]; !ok || f.Value != "" {
+		t.Errorf("empty fields should be kept for alignment, got %+v", f)
+	}
+
+	// No note without a sunset date
+	for _, f := range DetailFields(model.Module{}, "(in 30 days)") {
+		if f.Label == "Sunset Date:" && f.Value != "" {
+			t.Errorf("sunset = %q, want empty", f.Value)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package ui holds the palette, styles and detail fields shared by the TUI
+// and the text output of the cmvp subcommands, so both present a module
+// the same way.
+package ui
+
+import "github.com/charmbracelet/lipgloss"
+
+var (
+	// Color palette
+	PrimaryColor   = lipgloss.Color("#7D56F4") // Purple
+	SecondaryColor = lipgloss.Color("#04B575") // Green
+	WarningColor   = lipgloss.Color("#FFCC00") // Yellow
+	ErrorColor     = lipgloss.Color("#FF5F56") // Red
+	SubtleColor    = lipgloss.Color("#626262") // Gray
+
+	// Status colors
+	ActiveColor     = lipgloss.Color("#04B575") // Green
+	HistoricalColor = lipgloss.Color("#626262") // Gray
+	InProcessColor  = lipgloss.Color("#FFCC00") // Yellow
+
+	// Detail styles
+	DetailTitleStyle = lipgloss.NewStyle().
+				Foreground(PrimaryColor).
+				Bold(true).
+				MarginBottom(1)
+
+	DetailLabelStyle = lipgloss.NewStyle().
+				Foreground(SubtleColor).
+				Width(18)
+
+	DetailValueStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FAFAFA"))
+
+	DetailURLStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#00AAFF")).
+			Underline(true)
+
+	// Caveat warning style (important security warnings)
+	CaveatStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFFFFF")).
+			Background(lipgloss.Color("#FF6B6B")).
+			Padding(0, 1).
+			Bold(true)
+
+	// HeaderStyle titles sections and table headers
+	HeaderStyle = lipgloss.NewStyle().
+			Foreground(PrimaryColor).
+			Bold(true)
+)
@@ -0,0 +1,231 @@
+// Package watchlist persists the modules a user follows between sessions
+// and reports what changed about them since the last launch
+package watchlist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/fsutil"
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+// Entry is a watched module and how it looked when last seen
+type Entry struct {
+	Key               string    `json:"key"`
+	CertificateNumber string    `json:"certificate_number,omitempty"`
+	VendorName        string    `json:"vendor_name"`
+	ModuleName        string    `json:"module_name"`
+	AddedAt           time.Time `json:"added_at"`
+	Seen              Snapshot  `json:"seen"`
+}
+
+// Snapshot is the state of a module that changes are reported in
+type Snapshot struct {
+	Status       string `json:"status"`
+	Stage        string `json:"stage,omitempty"`
+	OverallLevel string `json:"overall_level,omitempty"`
+	SunsetDate   string `json:"sunset_date,omitempty"`
+	Caveat       string `json:"caveat,omitempty"`
+}
+
+// SnapshotOf captures the watched state of mod
+func SnapshotOf(mod model.Module) Snapshot {
+	s := Snapshot{
+		Status:       mod.Status.String(),
+		Stage:        string(mod.Stage),
+		OverallLevel: mod.OverallLevel.String(),
+		Caveat:       mod.Caveat,
+	}
+	if !mod.SunsetDate.IsZero() {
+		s.SunsetDate = mod.SunsetDate.Format("2006-01-02")
+	}
+	return s
+}
+
+// Changes describes how s differs from an earlier snapshot
+func (s Snapshot) Changes(earlier Snapshot) []string {
+	var changes []string
+	diff := func(field, was, now string) {
+		if was == now {
+			return
+		}
+		switch {
+		case was == "":
+			changes = append(changes, fmt.Sprintf("%s set to %s", field, now))
+		case now == "":
+			changes = append(changes, fmt.Sprintf("%s removed (was %s)", field, was))
+		default:
+			changes = append(changes, fmt.Sprintf("%s %s → %s", field, was, now))
+		}
+	}
+	diff("Status", earlier.Status, s.Status)
+	diff("Review stage", earlier.Stage, s.Stage)
+	diff("Level", earlier.OverallLevel, s.OverallLevel)
+	diff("Sunset", earlier.SunsetDate, s.SunsetDate)
+	if earlier.Caveat != s.Caveat {
+		changes = append(changes, "Caveat changed")
+	}
+	return changes
+}
+
+// Watchlist is the list of watched modules stored in a JSON file
+type Watchlist struct {
+	path    string
+	entries []Entry
+	// launch holds each entry's snapshot as loaded, so changes are reported
+	// against the previous session even after Refresh
+	launch map[string]Snapshot
+}
+
+// file is the on-disk format
+type file struct {
+	Entries []Entry `json:"entries"`
+}
+
+// DefaultPath returns watchlist.json in the cmvp directory under the user's
+// config dir ($XDG_CONFIG_HOME on Linux)
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cmvp", "watchlist.json"), nil
+}
+
+// Load reads the watchlist at path. A missing file is an empty watchlist.
+func Load(path string) (*Watchlist, error) {
+	w := &Watchlist{path: path, launch: make(map[string]Snapshot)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return w, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("reading watchlist %s: %w", path, err)
+	}
+	w.entries = f.Entries
+	for _, e := range w.entries {
+		w.launch[e.Key] = e.Seen
+	}
+	return w, nil
+}
+
+// Path returns the file the watchlist is saved to
+func (w *Watchlist) Path() string {
+	return w.path
+}
+
+// Entries returns the watched modules in the order they were added
+func (w *Watchlist) Entries() []Entry {
+	return w.entries
+}
+
+// Len returns the number of watched modules
+func (w *Watchlist) Len() int {
+	if w == nil {
+		return 0
+	}
+	return len(w.entries)
+}
+
+// Contains reports whether the module with key is watched. A nil watchlist
+// contains nothing.
+func (w *Watchlist) Contains(key string) bool {
+	if w == nil {
+		return false
+	}
+	for _, e := range w.entries {
+		if e.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Toggle starts or stops watching mod and reports whether it is now watched
+func (w *Watchlist) Toggle(mod model.Module, now time.Time) bool {
+	key := mod.Key()
+	for i, e := range w.entries {
+		if e.Key == key {
+			w.entries = append(w.entries[:i:i], w.entries[i+1:]...)
+			return false
+		}
+	}
+	w.entries = append(w.entries, Entry{
+		Key:               key,
+		CertificateNumber: mod.CertificateNumber,
+		VendorName:        mod.VendorName,
+		ModuleName:        mod.ModuleName,
+		AddedAt:           now,
+		Seen:              SnapshotOf(mod),
+	})
+	return true
+}
+
+// Changes describes what changed about the module with key since the
+// previous session. Modules added this session have no changes.
+func (w *Watchlist) Changes(mod model.Module) []string {
+	launch, ok := w.launch[mod.Key()]
+	if !ok {
+		return nil
+	}
+	return SnapshotOf(mod).Changes(launch)
+}
+
+// Refresh records the current state of the watched modules, to be compared
+// against on the next launch once saved. Watched in-process modules that
+// have since been validated are re-keyed by their new certificate number.
+func (w *Watchlist) Refresh(modules []model.Module) {
+	byKey := make(map[string]model.Module, len(modules))
+	byName := make(map[string]model.Module, len(modules))
+	for _, m := range modules {
+		byKey[m.Key()] = m
+		name := m.VendorName + "\x00" + m.ModuleName
+		if prev, ok := byName[name]; !ok || prev.CertificateNumber == "" {
+			byName[name] = m
+		}
+	}
+
+	for i, e := range w.entries {
+		mod, ok := byKey[e.Key]
+		if !ok && e.CertificateNumber == "" {
+			mod, ok = byName[e.VendorName+"\x00"+e.ModuleName]
+		}
+		if !ok {
+			continue
+		}
+		if key := mod.Key(); key != e.Key {
+			if s, ok := w.launch[e.Key]; ok {
+				delete(w.launch, e.Key)
+				w.launch[key] = s
+			}
+			w.entries[i].Key = key
+			w.entries[i].CertificateNumber = mod.CertificateNumber
+		}
+		w.entries[i].Seen = SnapshotOf(mod)
+	}
+}
+
+// Save writes the watchlist to its file
+func (w *Watchlist) Save() error {
+	dir := filepath.Dir(w.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(file{Entries: w.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return fsutil.WriteFileAtomic(w.path, data, 0o600)
+}
@@ -0,0 +1,108 @@
+package watchlist
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethanolivertroy/cmvp-tui/internal/model"
+)
+
+var launched = time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+func TestLoad_Missing(t *testing.T) {
+	w, err := Load(filepath.Join(t.TempDir(), "cmvp", "watchlist.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if w.Len() != 0 {
+		t.Errorf("Len() = %d, want an empty watchlist", w.Len())
+	}
+}
+
+func TestLoad_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("expected an error for a corrupt watchlist")
+	}
+}
+
+func TestWatchlist_ToggleSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cmvp", "watchlist.json")
+	w, _ := Load(path)
+
+	mod := model.Module{CertificateNumber: "4282", ModuleName: "OpenSSL FIPS Provider", Status: model.StatusActive}
+	if !w.Toggle(mod, launched) || !w.Contains("4282") {
+		t.Fatal("expected Toggle to start watching the module")
+	}
+	w.Toggle(model.Module{CertificateNumber: "1"}, launched)
+	if w.Toggle(model.Module{CertificateNumber: "1"}, launched) {
+		t.Error("expected a second Toggle to stop watching the module")
+	}
+	if err := w.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Entries(), w.Entries()) {
+		t.Errorf("loaded %+v, want %+v", loaded.Entries(), w.Entries())
+	}
+
+	var nilList *Watchlist
+	if nilList.Contains("4282") || nilList.Len() != 0 {
+		t.Error("a nil watchlist should be empty")
+	}
+}
+
+func TestWatchlist_Changes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watchlist.json")
+	w, _ := Load(path)
+	before := model.Module{CertificateNumber: "4282", Status: model.StatusActive, OverallLevel: model.NewSecurityLevel(1),
+		SunsetDate: time.Date(2029, 7, 11, 0, 0, 0, 0, time.UTC)}
+	pending := model.Module{VendorName: "V", ModuleName: "Pending", Status: model.StatusInProcess, Stage: model.StageCoordination}
+	w.Toggle(before, launched)
+	w.Toggle(pending, launched)
+	if got := w.Changes(before); got != nil {
+		t.Errorf("Changes() = %v, want none for modules added this session", got)
+	}
+	if err := w.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Next launch: the certificate went historical and the pending module was validated
+	w, _ = Load(path)
+	after := before
+	after.Status = model.StatusHistorical
+	after.SunsetDate = time.Time{}
+	validated := model.Module{CertificateNumber: "5001", VendorName: "V", ModuleName: "Pending", Status: model.StatusActive}
+	w.Refresh([]model.Module{after, validated})
+
+	want := []string{"Status Active → Historical", "Sunset removed (was 2029-07-11)"}
+	if got := w.Changes(after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Changes() = %v, want %v", got, want)
+	}
+	if !w.Contains("5001") {
+		t.Fatal("expected the validated module to be re-keyed by its certificate")
+	}
+	want = []string{"Status In Process → Active", "Review stage removed (was Coordination)"}
+	if got := w.Changes(validated); !reflect.DeepEqual(got, want) {
+		t.Errorf("Changes() = %v, want %v", got, want)
+	}
+
+	// Refresh updates what is saved, but not what this session reports
+	if w.Entries()[0].Seen.Status != "Historical" {
+		t.Errorf("Seen = %+v, want the current state", w.Entries()[0].Seen)
+	}
+	w.Refresh([]model.Module{after, validated})
+	if len(w.Changes(after)) != 2 {
+		t.Error("changes should be reported against the previous session all session")
+	}
+}
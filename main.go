@@ -1,20 +1,37 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/ethanolivertroy/cmvp-tui/internal/api"
+	"github.com/ethanolivertroy/cmvp-tui/internal/cli"
+	"github.com/ethanolivertroy/cmvp-tui/internal/snapshot"
 	"github.com/ethanolivertroy/cmvp-tui/internal/tui"
+	"github.com/ethanolivertroy/cmvp-tui/internal/watchlist"
 )
 
 // Version is set at build time via ldflags
 var version = "dev"
 
 func main() {
+	if len(os.Args) > 1 {
+		if cmd, ok := cli.Lookup(os.Args[1]); ok {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			code := cmd(ctx, os.Args[2:], os.Stdout, os.Stderr)
+			stop()
+			os.Exit(code)
+		}
+	}
+
 	showVersion := flag.Bool("version", false, "Print version and exit")
 	flag.BoolVar(showVersion, "v", false, "Print version and exit (shorthand)")
+	var sourceFlags cli.SourceFlags
+	sourceFlags.Register(flag.CommandLine)
 	flag.Parse()
 
 	if *showVersion {
@@ -22,8 +39,25 @@ func main() {
 		return
 	}
 
+	source, err := sourceFlags.NewSource()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(cli.ExitError)
+	}
+
+	model := tui.NewModel(source)
+	if wl, err := loadWatchlist(); err != nil {
+		// Printed before the alt screen takes over, so it shows on exit
+		fmt.Fprintf(os.Stderr, "Warning: watchlist disabled: %v\n", err)
+	} else {
+		model = model.WithWatchlist(wl)
+	}
+	if dir, err := api.DefaultCacheDir(); err == nil {
+		model = model.WithSnapshotPath(snapshot.LastSeenPath(dir))
+	}
+
 	p := tea.NewProgram(
-		tui.NewModel(),
+		model,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
@@ -33,3 +67,12 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// loadWatchlist reads the watchlist from the user's config dir
+func loadWatchlist() (*watchlist.Watchlist, error) {
+	path, err := watchlist.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return watchlist.Load(path)
+}